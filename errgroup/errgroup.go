@@ -10,12 +10,69 @@
 package errgroup
 
 import (
+	"bytes"
+	"cmp"
+	"container/list"
 	"context"
+	"errors"
 	"fmt"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/semaphore"
 )
 
-type token struct{}
+// errGoexit indicates the runtime.Goexit was called in
+// a function passed to Go.
+var errGoexit = errors.New("runtime.Goexit was called")
+
+// A PanicError is the value recovered from a panic in a function passed to
+// Go, together with the stack trace of the goroutine that panicked.
+//
+// If SetRecoverPanics(true) was called, Wait panics with a *PanicError if any
+// function in the group panicked.
+type PanicError struct {
+	Value any    // the value passed to panic
+	Stack []byte // the stack trace of the panicking goroutine
+}
+
+// Error implements error interface.
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("%v\n\n%s", p.Value, p.Stack)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (p *PanicError) Unwrap() error {
+	err, ok := p.Value.(error)
+	if !ok {
+		return nil
+	}
+
+	return err
+}
+
+func newPanicError(v any) *PanicError {
+	return &PanicError{Value: v, Stack: stack()}
+}
+
+// stack returns a formatted stack trace of the calling goroutine.
+func stack() []byte {
+	stack := debug.Stack()
+
+	// The first line of the stack trace is of the form "goroutine N [status]:"
+	// but by the time the stack is reported the goroutine may no longer exist
+	// and its status will have changed. Trim out the misleading line.
+	if line := bytes.IndexByte(stack[:], '\n'); line >= 0 {
+		stack = stack[line+1:]
+	}
+	return stack
+}
 
 // A Group is a collection of goroutines working on subtasks that are part of
 // the same overall task. A Group should not be reused for different tasks.
@@ -23,21 +80,255 @@ type token struct{}
 // A zero Group is valid, has no limit on the number of active goroutines,
 // and does not cancel on error.
 type Group struct {
+	ctx    context.Context // the associated Context, if any
 	cancel func(error)
 
+	parent *Group // see Sub
+
 	wg sync.WaitGroup
 
-	sem chan token
+	lim limiter
+
+	weighted     *semaphore.Weighted // see SetWeighted
+	weightedSize int64
+
+	policy FailurePolicy // see SetFailurePolicy; nil means FailFast
+
+	recoverPanics bool // see SetRecoverPanics
+
+	observer Observer // see SetObserver
+
+	stallThreshold time.Duration            // see SetStallHandler
+	stallHandler   func(running []TaskInfo) // see SetStallHandler
+
+	track atomic.Bool // whether to keep tasks; see Running
+
+	mu       sync.Mutex // protects the fields below
+	err      error
+	collect  bool // report all errors from Wait; see SetCollectErrors
+	errs     []error
+	abort    error              // first *PanicError or errGoexit, re-raised by Wait
+	canceled bool               // whether fail has canceled the Context
+	tasks    map[*task]struct{} // running function calls, including children's, if track is set
+	doneCh   chan struct{}      // closed when running drops to zero; see Done
+
+	// Counters reported by Stats.
+	waiting, active, succeeded, failed atomic.Int64
+
+	running atomic.Int64 // function calls added but not finished; see Done
+}
+
+// Stats describes the function calls of a Group at some instant.
+type Stats struct {
+	Active    int // functions currently running
+	Waiting   int // calls blocked waiting for a goroutine to be added
+	Succeeded int // functions that returned nil
+	Failed    int // functions that returned an error, panicked or called runtime.Goexit
+}
+
+// Stats reports the number of function calls in the group that are running,
+// waiting to be started, and finished. The counts are read individually and
+// so may not be mutually consistent while the group is active.
+//
+// The counts include the function calls of the group's child groups.
+func (g *Group) Stats() Stats {
+	return Stats{
+		Active:    int(g.active.Load()),
+		Waiting:   int(g.waiting.Load()),
+		Succeeded: int(g.succeeded.Load()),
+		Failed:    int(g.failed.Load()),
+	}
+}
+
+// A task is the record of a function call in a Group. It is only kept if
+// something needs it, as keeping it is a noticeable part of the cost of Go;
+// see newTask.
+type task struct {
+	TaskInfo
+	seq uint64 // orders tasks started at the same time
+}
+
+// newTask returns a record for a function call with the given name, or nil
+// if nothing in the group needs one.
+func (g *Group) newTask(name string) *task {
+	// Named calls are always recorded: GoNamed is costly anyway, and the
+	// names are what WaitContext reports.
+	if name == "" && g.observer == nil && g.stallHandler == nil && !g.tracked() {
+		return nil
+	}
+	return &task{TaskInfo: TaskInfo{Name: name}}
+}
+
+// tracked reports whether g or any of its ancestors keeps track of its
+// running function calls; see Running.
+func (g *Group) tracked() bool {
+	for p := g; p != nil; p = p.parent {
+		if p.track.Load() {
+			return true
+		}
+	}
+	return false
+}
+
+var taskSeq atomic.Uint64
+
+// TaskInfo describes a function call running in a Group.
+type TaskInfo struct {
+	Name    string    // the name passed to GoNamed, or ""
+	Started time.Time // when the function was started
+
+	// Stack is the stack trace of the goroutine that passed the function
+	// to the group. It is only recorded if a stall handler is set;
+	// see SetStallHandler.
+	Stack []byte
+}
+
+// limiter returns the limiter shared by g and all its relatives.
+func (g *Group) limiter() *limiter {
+	for g.parent != nil {
+		g = g.parent
+	}
+	return &g.lim
+}
+
+// acquire blocks until a new goroutine with the given priority can be added
+// to the group, or done is closed, and reports whether it can be added.
+func (g *Group) acquire(done <-chan struct{}, prio int) bool {
+	l := g.limiter()
+	if l.tryAcquire() {
+		return true
+	}
+	g.waiting.Add(1)
+	defer g.waiting.Add(-1)
+	return l.acquire(done, prio)
+}
+
+// launch calls f in a new goroutine once it can be added to the group, with
+// t (which may be nil) as its record. It reports false, without calling f,
+// if done is closed first.
+func (g *Group) launch(done <-chan struct{}, prio int, f func() error, t *task) bool {
+	g.queued(t)
+	if g.parent != nil {
+		// Child groups never wait for a goroutine to be added: the
+		// caller may itself be holding one of the slots, and waiting
+		// could deadlock once every slot is held by such a caller.
+		// Instead, call f in the caller's goroutine, which is already
+		// accounted for.
+		if g.limiter().tryAcquire() {
+			g.start(f, t)
+		} else {
+			g.call(f, t)
+		}
+		return true
+	}
+	if !g.acquire(done, prio) {
+		return false
+	}
+	g.start(f, t)
+	return true
+}
+
+// queued records that a function call with record t has been passed to the
+// group.
+func (g *Group) queued(t *task) {
+	if t == nil {
+		return
+	}
+	if g.stallHandler != nil {
+		t.Stack = stack()
+	}
+	if g.observer != nil {
+		g.observer.TaskQueued(t.TaskInfo)
+	}
+}
+
+// start calls f in a new goroutine. The caller must have acquired a slot
+// in g.limiter() for it.
+func (g *Group) start(f func() error, t *task) {
+	g.add(t)
+	go func() {
+		defer g.done(t)
+
+		// It is tempting to propagate panics from f()
+		// up to the goroutine that calls Wait, but
+		// it creates more problems than it solves:
+		// - it delays panics arbitrarily,
+		//   making bugs harder to detect;
+		// - it turns f's panic stack into a mere value,
+		//   hiding it from crash-monitoring tools;
+		// - it risks deadlocks that hide the panic entirely,
+		//   if f's panic leaves the program in a state
+		//   that prevents the Wait call from being reached.
+		// See #53757, #74275, #74304, #74306.
+		//
+		// So panics are only recovered, and re-raised by Wait,
+		// if SetRecoverPanics(true) was called.
+		g.started(t)
+		g.run(f, t)
+	}()
+}
+
+// call calls f in the current goroutine as a member of the group.
+func (g *Group) call(f func() error, t *task) {
+	g.add(t)
+	defer g.finish(t)
+	g.started(t)
+	g.run(f, t)
+}
 
-	errOnce sync.Once
-	err     error
+// add records a new active function call in g and its ancestors.
+func (g *Group) add(t *task) {
+	if t != nil {
+		t.Started = time.Now()
+		t.seq = taskSeq.Add(1)
+	}
+	for p := g; p != nil; p = p.parent {
+		p.active.Add(1)
+		p.wg.Add(1)
+		p.running.Add(1)
+		if t != nil {
+			p.mu.Lock()
+			if p.tasks == nil {
+				p.tasks = make(map[*task]struct{})
+			}
+			p.tasks[t] = struct{}{}
+			p.mu.Unlock()
+		}
+	}
 }
 
-func (g *Group) done() {
-	if g.sem != nil {
-		<-g.sem
+// started notifies the observer, if any, that the function call with record
+// t is about to be made. It must be called from the goroutine making it.
+func (g *Group) started(t *task) {
+	if g.observer != nil {
+		g.observer.TaskStarted(t.TaskInfo)
 	}
-	g.wg.Done()
+}
+
+// finish records that a function call added by add has returned.
+func (g *Group) finish(t *task) {
+	for p := g; p != nil; p = p.parent {
+		if t != nil {
+			p.mu.Lock()
+			delete(p.tasks, t)
+			p.mu.Unlock()
+		}
+		if p.running.Add(-1) == 0 {
+			p.mu.Lock()
+			// Another call may have been added since.
+			if p.doneCh != nil && p.running.Load() == 0 {
+				close(p.doneCh)
+				p.doneCh = nil
+			}
+			p.mu.Unlock()
+		}
+		p.wg.Done()
+	}
+}
+
+func (g *Group) done(t *task) {
+	g.limiter().release()
+	g.finish(t)
 }
 
 // WithContext returns a new Group and an associated Context derived from ctx.
@@ -46,18 +337,256 @@ func (g *Group) done() {
 // returns a non-nil error or the first time Wait returns, whichever occurs
 // first.
 func WithContext(ctx context.Context) (*Group, context.Context) {
-	ctx, cancel := context.WithCancelCause(ctx)
-	return &Group{cancel: cancel}, ctx
+	g := new(Group)
+	return g, g.withContext(ctx)
+}
+
+// withContext associates g with a new Context derived from ctx.
+func (g *Group) withContext(ctx context.Context) context.Context {
+	g.ctx, g.cancel = context.WithCancelCause(ctx)
+	return g.ctx
+}
+
+// fail records a non-nil error returned by a function in the group,
+// and cancels the Context if the failure policy says so.
+func (g *Group) fail(err error) {
+	g.mu.Lock()
+	if g.err == nil {
+		g.err = err
+	}
+	if g.collect {
+		g.errs = append(g.errs, err)
+	}
+	cancel := false
+	if g.cancel != nil && !g.canceled {
+		policy := g.policy
+		if policy == nil {
+			policy = FailFast()
+		}
+		// A recovered panic or runtime.Goexit is re-raised by Wait,
+		// so cancel whatever the policy says, to help the group reach
+		// Wait.
+		_, panicked := err.(*PanicError)
+		cancel = panicked || err == errGoexit || policy(g.Stats())
+		g.canceled = cancel
+	}
+	g.mu.Unlock()
+
+	if cancel {
+		g.cancel(err)
+		if g.observer != nil {
+			g.observer.GroupCanceled(err)
+		}
+	}
+	if g.parent != nil {
+		g.parent.fail(err)
+	}
+}
+
+// run calls f, recording its error and, if the group recovers panics, its
+// panic or call to runtime.Goexit.
+func (g *Group) run(f func() error, t *task) {
+	normalReturn := false
+	recovered := false
+	var err error
+
+	// use double-defer to distinguish panic from runtime.Goexit,
+	// more details see https://golang.org/cl/134395
+	defer func() {
+		if !normalReturn && !g.recoverPanics {
+			// f panicked or called runtime.Goexit, which cannot be
+			// told apart without recovering. Leave a panic to crash
+			// the program, and only count the call as failed.
+			for p := g; p != nil; p = p.parent {
+				p.active.Add(-1)
+				p.failed.Add(1)
+			}
+			return
+		}
+		// f invoked runtime.Goexit
+		if !normalReturn && !recovered {
+			err = errGoexit
+		}
+		for p := g; p != nil; p = p.parent {
+			if !normalReturn {
+				p.mu.Lock()
+				if p.abort == nil {
+					p.abort = err
+				}
+				p.mu.Unlock()
+			}
+			p.active.Add(-1)
+			if err != nil {
+				p.failed.Add(1)
+			} else {
+				p.succeeded.Add(1)
+			}
+		}
+		if g.observer != nil {
+			g.observer.TaskFinished(t.TaskInfo, err, time.Since(t.Started))
+		}
+		if err != nil {
+			g.fail(err)
+		}
+	}()
+
+	func() {
+		defer func() {
+			if !normalReturn && g.recoverPanics {
+				// Take the stack trace now, while the panicking frames
+				// are still on the stack; see singleflight.doCall.
+				if r := recover(); r != nil {
+					err = newPanicError(r)
+				}
+			}
+		}()
+
+		err = f()
+		normalReturn = true
+	}()
+
+	if !normalReturn {
+		recovered = true
+	}
 }
 
 // Wait blocks until all function calls from the Go method have returned, then
 // returns the first non-nil error (if any) from them.
+//
+// If SetCollectErrors(true) was called, Wait instead returns all non-nil
+// errors, in the order they were returned, joined with [errors.Join].
+//
+// If SetRecoverPanics(true) was called and any of the functions panicked,
+// Wait panics with a *[PanicError] holding the first panic value and the
+// stack of the goroutine that panicked. If any of the functions called
+// [runtime.Goexit], Wait calls it too.
 func (g *Group) Wait() error {
+	stop := g.watchStalls()
 	g.wg.Wait()
+	stop()
+	g.mu.Lock()
+	err, abort := g.err, g.abort
+	g.mu.Unlock()
 	if g.cancel != nil {
-		g.cancel(g.err)
+		g.cancel(err)
+	}
+	if abort == errGoexit {
+		runtime.Goexit()
+	} else if abort != nil {
+		panic(abort)
+	}
+	if g.collect {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		return errors.Join(g.errs...)
+	}
+	return err
+}
+
+// WaitContext is like Wait, but returns early if ctx is done before all
+// function calls from the Go method have returned. In that case it returns
+// a *[WaitError] describing the calls still running, and the group is left
+// as it is: its Context is not canceled and Wait may still be called.
+//
+// The calls listed in the WaitError are those Running would report, which
+// include every call started by GoNamed; see Running. Its Count includes
+// the other calls still running too.
+func (g *Group) WaitContext(ctx context.Context) error {
+	g.track.Store(true)
+	stop := g.watchStalls()
+	defer stop()
+	select {
+	case <-g.Done():
+		return g.Wait()
+	case <-ctx.Done():
 	}
-	return g.err
+	select {
+	case <-g.Done():
+		// Both are done; prefer the result of the group.
+		return g.Wait()
+	default:
+	}
+	running := g.Running()
+	count := max(int(g.running.Load()), len(running))
+	return &WaitError{Err: context.Cause(ctx), Running: running, Count: count}
+}
+
+// Done returns a channel that is closed when no function calls in the group
+// are running, that is, when Wait would not block.
+//
+// A later call to Go starts a new round: Done must be called again to obtain
+// a channel for it.
+func (g *Group) Done() <-chan struct{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.running.Load() == 0 {
+		return closedCh
+	}
+	if g.doneCh == nil {
+		g.doneCh = make(chan struct{})
+	}
+	return g.doneCh
+}
+
+var closedCh = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}()
+
+// Running returns the function calls in the group (including its child
+// groups) that are currently running, in the order they were started.
+//
+// Calls started by GoNamed are always reported. To keep Go cheap, a group
+// only keeps track of its other running calls once Running or WaitContext
+// has been called, or a stall handler has been set; calls started before
+// then are not reported. To have every call reported, call Running once
+// before the first call to Go.
+func (g *Group) Running() []TaskInfo {
+	g.track.Store(true)
+	g.mu.Lock()
+	tasks := make([]*task, 0, len(g.tasks))
+	for t := range g.tasks {
+		tasks = append(tasks, t)
+	}
+	g.mu.Unlock()
+	slices.SortFunc(tasks, func(a, b *task) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+	infos := make([]TaskInfo, len(tasks))
+	for i, t := range tasks {
+		infos[i] = t.TaskInfo
+	}
+	return infos
+}
+
+// A WaitError is returned by WaitContext when its Context is done before all
+// the function calls in the group have returned.
+type WaitError struct {
+	Err     error      // the cause of the Context's cancellation
+	Running []TaskInfo // the function calls still running, as reported by Group.Running
+	Count   int        // the number of function calls still running, including any not in Running
+}
+
+func (e *WaitError) Error() string {
+	var names []string
+	unnamed := max(e.Count-len(e.Running), 0)
+	for _, t := range e.Running {
+		if t.Name == "" {
+			unnamed++
+		} else {
+			names = append(names, t.Name)
+		}
+	}
+	if unnamed > 0 {
+		names = append(names, fmt.Sprintf("%d unnamed", unnamed))
+	}
+	count := max(e.Count, len(e.Running))
+	return fmt.Sprintf("errgroup: %d functions still running [%s]: %v", count, strings.Join(names, ", "), e.Err)
+}
+
+func (e *WaitError) Unwrap() error {
+	return e.Err
 }
 
 // Go calls the given function in a new goroutine.
@@ -68,37 +597,108 @@ func (g *Group) Wait() error {
 //
 // The first goroutine in the group that returns a non-nil error will
 // cancel the associated Context, if any. The error will be returned
-// by Wait.
+// by Wait. A panic in f crashes the program, unless SetRecoverPanics(true)
+// was called.
+//
+// On a child group created by Sub, Go never blocks. If no goroutine can be
+// added, because the shared limit is reached or is zero, Go calls f in the
+// calling goroutine and returns once f has returned. This holds whatever
+// goroutine Go is called from, and for GoContext, GoPriority and GoNamed too.
 func (g *Group) Go(f func() error) {
-	if g.sem != nil {
-		g.sem <- token{}
-	}
+	g.launch(nil, 0, f, g.newTask(""))
+}
 
-	g.wg.Add(1)
-	go func() {
-		defer g.done()
+// GoPriority is like Go, but if the group is at its limit, f is started
+// ahead of every waiting function with a lower priority p. Functions with
+// equal priority are started in the order they were passed to the group.
+// Go and the other methods use priority 0.
+//
+// Priority only affects the order in which waiting functions are started;
+// it does not preempt functions that are already running. On a child group,
+// which never waits for a goroutine to be added (see Go), p has no effect.
+func (g *Group) GoPriority(p int, f func() error) {
+	g.launch(nil, p, f, g.newTask(""))
+}
 
-		// It is tempting to propagate panics from f()
-		// up to the goroutine that calls Wait, but
-		// it creates more problems than it solves:
-		// - it delays panics arbitrarily,
-		//   making bugs harder to detect;
-		// - it turns f's panic stack into a mere value,
-		//   hiding it from crash-monitoring tools;
-		// - it risks deadlocks that hide the panic entirely,
-		//   if f's panic leaves the program in a state
-		//   that prevents the Wait call from being reached.
-		// See #53757, #74275, #74304, #74306.
+// GoNamed is like Go, but names the function call.
+//
+// While f runs, its goroutine carries the [runtime/pprof] label "task" set
+// to name, so that it can be told apart in goroutine dumps and profiles.
+// A non-nil error returned by f is wrapped with the name, as in
+// "name: error", before it is recorded by the group.
+func (g *Group) GoNamed(name string, f func() error) {
+	g.launch(nil, 0, g.named(name, f), g.newTask(name))
+}
 
-		if err := f(); err != nil {
-			g.errOnce.Do(func() {
-				g.err = err
-				if g.cancel != nil {
-					g.cancel(g.err)
-				}
-			})
+// named returns a function that calls f under the given task name.
+func (g *Group) named(name string, f func() error) func() error {
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return func() (err error) {
+		pprof.Do(ctx, pprof.Labels("task", name), func(context.Context) {
+			err = f()
+		})
+		if err != nil {
+			err = fmt.Errorf("%s: %w", name, err)
 		}
-	}()
+		return err
+	}
+}
+
+// GoContext is like Go, but gives up waiting for the new goroutine to be
+// added once ctx is done. In that case f is not called and GoContext returns
+// the cause of ctx's cancellation (see [context.Cause]); otherwise it returns
+// nil.
+//
+// ctx is typically the Context returned by WithContext, so that a producer
+// stops adding work to a group that has already failed.
+//
+// On a child group, GoContext never waits (see Go): if ctx is not yet done,
+// f is either started in a new goroutine or called in the calling goroutine,
+// and in the latter case GoContext returns once f has returned, however
+// long that takes.
+func (g *Group) GoContext(ctx context.Context, f func() error) error {
+	if ctx.Err() != nil {
+		// Prefer to fail even if a slot is available right away.
+		return context.Cause(ctx)
+	}
+	if !g.launch(ctx.Done(), 0, f, g.newTask("")) {
+		return context.Cause(ctx)
+	}
+	return nil
+}
+
+// GoWeighted is like Go, but first acquires a weight of n from the semaphore
+// configured with SetWeighted, blocking until it is available. The weight is
+// released when f returns.
+//
+// The weight is acquired before waiting for the limit set by SetLimit, so
+// a call blocked on the limit holds its weight meanwhile.
+//
+// GoWeighted panics if SetWeighted has not been called, if n is negative,
+// or if n exceeds the size of the semaphore, as the weight could never be
+// acquired.
+func (g *Group) GoWeighted(n int64, f func() error) {
+	s := g.weighted
+	if s == nil {
+		panic("errgroup: GoWeighted called without SetWeighted")
+	}
+	if n < 0 {
+		panic(fmt.Sprintf("errgroup: GoWeighted weight %d is negative", n))
+	}
+	if n > g.weightedSize {
+		panic(fmt.Sprintf("errgroup: GoWeighted weight %d exceeds semaphore size %d", n, g.weightedSize))
+	}
+	g.waiting.Add(1)
+	// Acquire cannot fail with a Context that is never done.
+	s.Acquire(context.Background(), n)
+	g.waiting.Add(-1)
+	g.launch(nil, 0, func() error {
+		defer s.Release(n)
+		return f()
+	}, g.newTask(""))
 }
 
 // TryGo calls the given function in a new goroutine only if the number of
@@ -106,28 +706,12 @@ func (g *Group) Go(f func() error) {
 //
 // The return value reports whether the goroutine was started.
 func (g *Group) TryGo(f func() error) bool {
-	if g.sem != nil {
-		select {
-		case g.sem <- token{}:
-			// Note: this allows barging iff channels in general allow barging.
-		default:
-			return false
-		}
+	if !g.limiter().tryAcquire() {
+		return false
 	}
-
-	g.wg.Add(1)
-	go func() {
-		defer g.done()
-
-		if err := f(); err != nil {
-			g.errOnce.Do(func() {
-				g.err = err
-				if g.cancel != nil {
-					g.cancel(g.err)
-				}
-			})
-		}
-	}()
+	t := g.newTask("")
+	g.queued(t)
+	g.start(f, t)
 	return true
 }
 
@@ -138,14 +722,366 @@ func (g *Group) TryGo(f func() error) bool {
 // Any subsequent call to the Go method will block until it can add an active
 // goroutine without exceeding the configured limit.
 //
-// The limit must not be modified while any goroutines in the group are active.
+// The limit may be changed while goroutines in the group are active.
+// Raising it admits blocked callers of Go right away; lowering it below the
+// number of active goroutines takes effect as they return.
+//
+// Child groups share the limit of their parent; calling SetLimit on a child
+// group changes the limit for the whole tree of groups. Go on a child group
+// does not block on the limit, even a limit of zero, but calls the function
+// in the calling goroutine instead; see Go.
 func (g *Group) SetLimit(n int) {
-	if n < 0 {
-		g.sem = nil
-		return
+	g.limiter().setLimit(n)
+}
+
+// Sub returns a new child group of g and an associated Context derived from
+// g's Context (or from [context.Background] if g has none).
+//
+// The child group is meant for nested fan-out from within a function running
+// in g, such as a recursive walk of a tree:
+//   - the child's Context is canceled when g's is, and otherwise behaves like
+//     the Context returned by WithContext;
+//   - every error (and recovered panic) in the child is also reported to
+//     g, as though it had occurred in g itself, and g.Wait waits for the
+//     child's functions as well as its own;
+//   - the child shares g's limit on active goroutines. When no goroutine can
+//     be added, Go on the child calls the function in the calling goroutine
+//     instead of blocking, so nested work never deadlocks waiting for slots
+//     held by its own ancestors.
+//
+// The child group starts with g's Observer, stall handler and semaphore for
+// GoWeighted, if any, and recovers panics if g does.
+func (g *Group) Sub() (*Group, context.Context) {
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	child := &Group{
+		parent:         g,
+		observer:       g.observer,
+		stallThreshold: g.stallThreshold,
+		stallHandler:   g.stallHandler,
+		recoverPanics:  g.recoverPanics,
+		weighted:       g.weighted,
+		weightedSize:   g.weightedSize,
 	}
-	if active := len(g.sem); active != 0 {
-		panic(fmt.Errorf("errgroup: modify limit while %v goroutines in the group are still active", active))
+	child.track.Store(g.stallHandler != nil)
+	return child, child.withContext(ctx)
+}
+
+// SetCollectErrors controls whether Wait reports every error returned by the
+// functions in this group, rather than only the first. When collect is true,
+// Wait returns the errors joined with [errors.Join], so that [errors.Is] and
+// [errors.As] match any of them.
+//
+// The associated Context, if any, is still canceled by the first error.
+//
+// SetCollectErrors must be called before any calls to Go or TryGo.
+func (g *Group) SetCollectErrors(collect bool) {
+	g.collect = collect
+}
+
+// SetRecoverPanics controls whether the group recovers panics in its
+// functions. By default a panic crashes the program from the goroutine
+// that panicked, as it would outside a group.
+//
+// When enable is true, a panic in a function is recovered, together with
+// its stack trace, and cancels the associated Context, if any, whatever the
+// failure policy. Wait then panics with a *[PanicError] once all the
+// functions have returned. The same goes for a function that calls
+// [runtime.Goexit], for which Wait calls runtime.Goexit. Note that this
+// delays the panic until Wait is reached, and that a panic which leaves the
+// program unable to reach Wait is never reported.
+//
+// SetRecoverPanics must be called before any calls to Go or TryGo.
+func (g *Group) SetRecoverPanics(enable bool) {
+	g.recoverPanics = enable
+}
+
+// SetWeighted sets the semaphore from which GoWeighted acquires the weight of
+// each of its functions, so that admission to the group is bounded by the
+// total weight of the running functions rather than only their number.
+// size must be the size s was created with. The semaphore may be shared with
+// other groups.
+//
+// Functions started by GoWeighted also count towards the limit set by
+// SetLimit, if any. Functions started by Go and TryGo do not use the
+// semaphore.
+//
+// SetWeighted must not be called while any functions started by GoWeighted
+// are active.
+func (g *Group) SetWeighted(s *semaphore.Weighted, size int64) {
+	g.weighted = s
+	g.weightedSize = size
+}
+
+// A limiter bounds the number of active goroutines in a group.
+// Unlike a buffered channel, its limit may change while goroutines are active.
+//
+// A zero limiter has no limit.
+type limiter struct {
+	mu      sync.Mutex
+	limited bool
+	limit   int
+	active  int
+	waiters list.List // of *waiter, by decreasing priority then arrival
+}
+
+// A waiter is a goroutine blocked in limiter.acquire.
+type waiter struct {
+	prio  int
+	ready chan struct{} // closed when the waiter is admitted
+}
+
+// available reports whether another goroutine may become active.
+// l.mu must be held.
+func (l *limiter) available() bool {
+	return !l.limited || l.active < l.limit
+}
+
+// acquire blocks until another goroutine may become active, or done is
+// closed. It reports whether the goroutine was admitted.
+// A nil done channel blocks until the goroutine is admitted.
+//
+// Waiters are admitted in order of decreasing prio, and in arrival order
+// among equal prio.
+func (l *limiter) acquire(done <-chan struct{}, prio int) bool {
+	l.mu.Lock()
+	if l.waiters.Len() == 0 && l.available() {
+		l.active++
+		l.mu.Unlock()
+		return true
+	}
+	ready := make(chan struct{})
+	elem := l.enqueue(&waiter{prio: prio, ready: ready})
+	l.mu.Unlock()
+
+	select {
+	case <-ready:
+		// Admitted. Check that done isn't already closed.
+		select {
+		case <-done:
+			l.release()
+			return false
+		default:
+		}
+		return true
+	case <-done:
+		l.mu.Lock()
+		select {
+		case <-ready:
+			// Admitted after done was closed.
+			// Pretend we weren't and give the slot back.
+			l.active--
+			l.admit()
+		default:
+			l.waiters.Remove(elem)
+		}
+		l.mu.Unlock()
+		return false
+	}
+}
+
+// enqueue adds w to the waiters behind every waiter with the same or higher
+// priority. l.mu must be held.
+func (l *limiter) enqueue(w *waiter) *list.Element {
+	// Most waiters share a priority, so search from the back.
+	for e := l.waiters.Back(); e != nil; e = e.Prev() {
+		if e.Value.(*waiter).prio >= w.prio {
+			return l.waiters.InsertAfter(w, e)
+		}
+	}
+	return l.waiters.PushFront(w)
+}
+
+// tryAcquire makes another goroutine active without blocking,
+// reporting whether it could.
+func (l *limiter) tryAcquire() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.waiters.Len() != 0 || !l.available() {
+		return false
+	}
+	l.active++
+	return true
+}
+
+// size returns the limit, or -1 if there is none.
+func (l *limiter) size() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.limited {
+		return -1
+	}
+	return l.limit
+}
+
+// release records that an active goroutine has returned.
+func (l *limiter) release() {
+	l.mu.Lock()
+	l.active--
+	l.admit()
+	l.mu.Unlock()
+}
+
+// setLimit changes the limit, admitting waiters if it was raised.
+// A negative n indicates no limit.
+func (l *limiter) setLimit(n int) {
+	l.mu.Lock()
+	l.limited = n >= 0
+	l.limit = n
+	l.admit()
+	l.mu.Unlock()
+}
+
+// admit makes waiters active, in queue order, while the limit allows.
+// l.mu must be held.
+func (l *limiter) admit() {
+	for l.available() {
+		next := l.waiters.Front()
+		if next == nil {
+			break // No more waiters blocked.
+		}
+		l.active++
+		l.waiters.Remove(next)
+		close(next.Value.(*waiter).ready)
+	}
+}
+
+// A FailurePolicy decides whether a Group with an associated Context cancels
+// it after a function in the group fails. It is called with the group's Stats,
+// which already count the failure, and reports whether to cancel.
+type FailurePolicy func(Stats) bool
+
+// FailFast returns a FailurePolicy that cancels on the first failure.
+// This is the default.
+func FailFast() FailurePolicy {
+	return func(s Stats) bool { return s.Failed > 0 }
+}
+
+// NeverCancel returns a FailurePolicy that never cancels on failure.
+// The Context is still canceled when Wait returns.
+func NeverCancel() FailurePolicy {
+	return func(Stats) bool { return false }
+}
+
+// CancelAfter returns a FailurePolicy that tolerates up to n failures and
+// cancels on the next one.
+func CancelAfter(n int) FailurePolicy {
+	return func(s Stats) bool { return s.Failed > n }
+}
+
+// CancelAboveRatio returns a FailurePolicy that cancels once the fraction of
+// finished functions that failed exceeds ratio. To avoid canceling on the
+// first few results, it does not cancel until at least minFinished functions
+// have finished.
+func CancelAboveRatio(ratio float64, minFinished int) FailurePolicy {
+	return func(s Stats) bool {
+		finished := s.Succeeded + s.Failed
+		return finished >= minFinished && float64(s.Failed) > ratio*float64(finished)
+	}
+}
+
+// SetFailurePolicy sets the policy that decides when a failing function
+// cancels the Context associated with the group. A nil policy means
+// [FailFast], which cancels on the first failure.
+//
+// The policy only affects cancellation: Wait still reports the first error,
+// or every error if SetCollectErrors(true) was called. If SetRecoverPanics(true)
+// was called, a function that panics or calls [runtime.Goexit] cancels the
+// Context whatever the policy.
+//
+// SetFailurePolicy must be called before any calls to Go or TryGo.
+func (g *Group) SetFailurePolicy(p FailurePolicy) {
+	g.policy = p
+}
+
+// An Observer is notified of the life cycle of the function calls in a Group,
+// for example to log them or to record them as [runtime/trace] regions.
+//
+// TaskStarted and TaskFinished are called from the goroutine that calls the
+// function, so that they may start and end a [runtime/trace] region around
+// it. TaskQueued is called from the goroutine passing the function to the
+// group, and GroupCanceled from the goroutine of the function that failed.
+// The methods may be called concurrently and should return quickly.
+type Observer interface {
+	// TaskQueued is called when a function is passed to the group, before
+	// waiting for a goroutine to be added for it. The Started field of t is
+	// not yet set. A function passed to GoContext that gives up waiting
+	// is queued but never started.
+	TaskQueued(t TaskInfo)
+
+	// TaskStarted is called when a function is about to be called.
+	TaskStarted(t TaskInfo)
+
+	// TaskFinished is called when a function has returned, with its error
+	// (or *PanicError) and how long it ran. Unless the group recovers panics
+	// (see SetRecoverPanics), it is not called for a function that panics
+	// or calls runtime.Goexit.
+	TaskFinished(t TaskInfo, err error, d time.Duration)
+
+	// GroupCanceled is called when a failing function cancels the group's
+	// Context, with the cause of the cancellation. It is not called for
+	// the cancellation done by Wait.
+	GroupCanceled(cause error)
+}
+
+// SetObserver sets the Observer notified of the function calls in the group.
+// A nil Observer disables notifications.
+//
+// SetObserver must be called before any calls to Go or TryGo.
+func (g *Group) SetObserver(o Observer) {
+	g.observer = o
+}
+
+// SetStallHandler enables diagnostics for function calls that keep a Wait
+// from returning. Once a call to Wait or WaitContext has been blocked for
+// threshold, and every threshold after that, handler is called with the
+// function calls still running. Each TaskInfo includes the stack trace of the
+// goroutine that passed the function to the group, and how long the function
+// has been running can be computed from its Started time.
+//
+// Recording stack traces makes starting functions slower, so this is meant
+// for debugging, or for finding leaked goroutines in tests.
+// A nil handler disables the diagnostics. SetStallHandler panics if handler
+// is not nil and threshold is not positive.
+//
+// SetStallHandler must be called before any calls to Go or TryGo.
+func (g *Group) SetStallHandler(threshold time.Duration, handler func(running []TaskInfo)) {
+	if handler != nil && threshold <= 0 {
+		panic(fmt.Sprintf("errgroup: non-positive stall threshold %v", threshold))
+	}
+	g.stallThreshold = threshold
+	g.stallHandler = handler
+	if handler != nil {
+		g.track.Store(true)
+	}
+}
+
+// watchStalls calls the stall handler, if any, until stop is called.
+func (g *Group) watchStalls() (stop func()) {
+	if g.stallHandler == nil {
+		return func() {}
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(g.stallThreshold)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if running := g.Running(); len(running) > 0 {
+					g.stallHandler(running)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
 	}
-	g.sem = make(chan token, n)
 }
@@ -8,13 +8,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"golang.org/x/sync/errgroup"
+	"golang.org/x/sync/semaphore"
 )
 
 var (
@@ -101,6 +108,52 @@ func ExampleGroup_parallel() {
 	// video result for "golang"
 }
 
+// slogObserver is an errgroup.Observer that logs to a slog.Logger.
+type slogObserver struct{ log *slog.Logger }
+
+func (o slogObserver) TaskQueued(t errgroup.TaskInfo) {
+	o.log.Debug("task queued", "name", t.Name)
+}
+
+func (o slogObserver) TaskStarted(t errgroup.TaskInfo) {
+	o.log.Debug("task started", "name", t.Name)
+}
+
+func (o slogObserver) TaskFinished(t errgroup.TaskInfo, err error, d time.Duration) {
+	o.log.Info("task finished", "name", t.Name, "err", err)
+}
+
+func (o slogObserver) GroupCanceled(cause error) {
+	o.log.Warn("group canceled", "cause", cause)
+}
+
+// Observer illustrates logging the life cycle of the function calls in a
+// Group with log/slog.
+func ExampleGroup_SetObserver() {
+	log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{} // Keep the output stable.
+			}
+			return a
+		},
+	}))
+
+	g, _ := errgroup.WithContext(context.Background())
+	g.SetObserver(slogObserver{log})
+	g.GoNamed("fetch-users", func() error {
+		return errors.New("connection refused")
+	})
+	g.Wait()
+
+	// Output:
+	// level=DEBUG msg="task queued" name=fetch-users
+	// level=DEBUG msg="task started" name=fetch-users
+	// level=INFO msg="task finished" name=fetch-users err="fetch-users: connection refused"
+	// level=WARN msg="group canceled" cause="fetch-users: connection refused"
+}
+
 func TestZeroGroup(t *testing.T) {
 	err1 := errors.New("errgroup_test: 1")
 	err2 := errors.New("errgroup_test: 2")
@@ -250,6 +303,573 @@ func TestGoLimit(t *testing.T) {
 	}
 }
 
+func TestSetLimitWhileActive(t *testing.T) {
+	g := &errgroup.Group{}
+	g.SetLimit(1)
+	var active atomic.Int32
+	var limit atomic.Int32
+	limit.Store(1)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	task := func() error {
+		n := active.Add(1)
+		defer active.Add(-1)
+		if l := limit.Load(); n > l {
+			return fmt.Errorf("saw %d active goroutines; want ≤ %d", n, l)
+		}
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	g.Go(task)
+	<-started
+	go func() {
+		g.Go(task)
+		g.Go(task)
+	}()
+
+	// Raising the limit admits the blocked calls right away.
+	limit.Store(3)
+	g.SetLimit(3)
+	<-started
+	<-started
+
+	// Lowering the limit takes effect as the active goroutines return.
+	limit.Store(1)
+	g.SetLimit(1)
+	go g.Go(task)
+	release <- struct{}{}
+	release <- struct{}{}
+	select {
+	case <-started:
+		t.Fatalf("goroutine started with %d active; want limit of 1 respected", active.Load()+1)
+	case <-time.After(10 * time.Millisecond):
+	}
+	release <- struct{}{}
+	<-started
+	release <- struct{}{}
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGoPriority(t *testing.T) {
+	g := &errgroup.Group{}
+	g.SetLimit(1)
+	release := make(chan struct{})
+	g.Go(func() error { <-release; return nil })
+
+	var (
+		mu    sync.Mutex
+		order []string
+	)
+	record := func(name string) func() error {
+		return func() error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return nil
+		}
+	}
+	queue := []struct {
+		prio int
+		name string
+	}{
+		{0, "bulk1"},
+		{0, "bulk2"},
+		{5, "urgent1"},
+		{-1, "backfill"},
+		{5, "urgent2"},
+		{1, "interactive"},
+	}
+	var callers sync.WaitGroup
+	for i, q := range queue {
+		callers.Go(func() { g.GoPriority(q.prio, record(q.name)) })
+		// Queue the calls one at a time, so that their arrival order is known.
+		waitForStats(t, g, errgroup.Stats{Active: 1, Waiting: i + 1})
+	}
+	close(release)
+	callers.Wait()
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"urgent1", "urgent2", "interactive", "bulk1", "bulk2", "backfill"}
+	if !slices.Equal(order, want) {
+		t.Errorf("functions ran in order %q; want %q", order, want)
+	}
+}
+
+func TestGoNamed(t *testing.T) {
+	errRefused := errors.New("connection refused")
+
+	g := new(errgroup.Group)
+	var profile strings.Builder
+	g.GoNamed("fetch-users", func() error {
+		pprof.Lookup("goroutine").WriteTo(&profile, 1)
+		return errRefused
+	})
+	g.GoNamed("fetch-groups", func() error { return nil })
+
+	err := g.Wait()
+	if err == nil || err.Error() != "fetch-users: connection refused" {
+		t.Errorf("g.Wait() = %v; want %q", err, "fetch-users: connection refused")
+	}
+	if !errors.Is(err, errRefused) {
+		t.Errorf("errors.Is(%v, %v) = false; want true", err, errRefused)
+	}
+	if want := `"task":"fetch-users"`; !strings.Contains(profile.String(), want) {
+		t.Errorf("goroutine profile does not contain label %s:\n%s", want, profile.String())
+	}
+}
+
+func TestStats(t *testing.T) {
+	g := &errgroup.Group{}
+	g.SetLimit(2)
+	release := make(chan struct{})
+	for i := 0; i < 2; i++ {
+		g.Go(func() error {
+			<-release
+			return nil
+		})
+	}
+	blocked := make(chan struct{})
+	go func() {
+		defer close(blocked)
+		g.Go(func() error { return errors.New("group_test: doomed") })
+	}()
+
+	waitForStats(t, g, errgroup.Stats{Active: 2, Waiting: 1})
+	close(release)
+	<-blocked
+	g.Wait()
+	if got, want := g.Stats(), (errgroup.Stats{Succeeded: 2, Failed: 1}); got != want {
+		t.Errorf("g.Stats() = %+v; want %+v", got, want)
+	}
+}
+
+// waitForStats waits for g.Stats() to report want.
+func waitForStats(t *testing.T, g *errgroup.Group, want errgroup.Stats) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		got := g.Stats()
+		if got == want {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("g.Stats() = %+v; want %+v", got, want)
+		}
+		time.Sleep(1 * time.Millisecond)
+	}
+}
+
+func TestSub(t *testing.T) {
+	const (
+		limit  = 2
+		fanout = 3
+		depth  = 5
+	)
+
+	g := &errgroup.Group{}
+	g.SetLimit(limit)
+	var visited int32
+	var walk func(g *errgroup.Group, level int) error
+	walk = func(g *errgroup.Group, level int) error {
+		atomic.AddInt32(&visited, 1)
+		if level == depth {
+			return nil
+		}
+		sub, _ := g.Sub()
+		for i := 0; i < fanout; i++ {
+			sub.Go(func() error {
+				return walk(sub, level+1)
+			})
+		}
+		return sub.Wait()
+	}
+	// Every nested call runs with the limit saturated;
+	// this deadlocks if the children block waiting for slots.
+	g.Go(func() error { return walk(g, 0) })
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := 0
+	for i, n := 0, 1; i <= depth; i, n = i+1, n*fanout {
+		want += n
+	}
+	if visited != int32(want) {
+		t.Errorf("visited %d nodes; want %d", visited, want)
+	}
+}
+
+func TestSubInline(t *testing.T) {
+	g := new(errgroup.Group)
+	g.SetLimit(0)
+	child, _ := g.Sub()
+
+	// With no goroutine available, every method calls f in the caller.
+	caller := goroutineID()
+	inline := func() error {
+		if id := goroutineID(); id != caller {
+			return fmt.Errorf("f ran in goroutine %s; want the caller's, %s", id, caller)
+		}
+		return nil
+	}
+	child.Go(inline)
+	child.GoPriority(1, inline)
+	child.GoNamed("inline", inline)
+	if err := child.GoContext(context.Background(), inline); err != nil {
+		t.Errorf("child.GoContext() = %v; want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := child.GoContext(ctx, func() error {
+		t.Error("function passed to GoContext was called after ctx was done")
+		return nil
+	}); err != context.Canceled {
+		t.Errorf("child.GoContext() = %v; want %v", err, context.Canceled)
+	}
+
+	if err := g.Wait(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSubErrors(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	g, ctx := errgroup.WithContext(context.Background())
+	sub, subCtx := g.Sub()
+	other, otherCtx := g.Sub()
+	other.Go(func() error {
+		<-otherCtx.Done()
+		return nil
+	})
+	sub.Go(func() error { return errDoom })
+
+	if err := sub.Wait(); err != errDoom {
+		t.Errorf("sub.Wait() = %v; want %v", err, errDoom)
+	}
+	if err := g.Wait(); err != errDoom {
+		t.Errorf("g.Wait() = %v; want %v", err, errDoom)
+	}
+	for name, ctx := range map[string]context.Context{"ctx": ctx, "subCtx": subCtx, "otherCtx": otherCtx} {
+		if cause := context.Cause(ctx); cause != errDoom {
+			t.Errorf("context.Cause(%s) = %v; want %v", name, cause, errDoom)
+		}
+	}
+	if got := g.Stats(); got.Failed != 1 || got.Succeeded != 1 {
+		t.Errorf("g.Stats() = %+v; want the child groups' calls included", got)
+	}
+}
+
+func TestWaitContext(t *testing.T) {
+	g := new(errgroup.Group)
+	release := make(chan struct{})
+	g.GoNamed("fast", func() error { return nil })
+	g.GoNamed("stuck", func() error {
+		<-release
+		return nil
+	})
+	g.Go(func() error {
+		<-release
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := g.WaitContext(ctx)
+	var werr *errgroup.WaitError
+	if !errors.As(err, &werr) {
+		t.Fatalf("g.WaitContext() = %v; want a *errgroup.WaitError", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("errors.Is(%v, context.DeadlineExceeded) = false; want true", err)
+	}
+	var names []string
+	for _, task := range werr.Running {
+		names = append(names, task.Name)
+	}
+	if got, want := strings.Join(names, ","), "stuck"; got != want {
+		t.Errorf("running tasks = %q; want %q", got, want)
+	}
+	if werr.Count != 2 {
+		t.Errorf("WaitError.Count = %d; want 2", werr.Count)
+	}
+	if want := "errgroup: 2 functions still running [stuck, 1 unnamed]: context deadline exceeded"; err.Error() != want {
+		t.Errorf("g.WaitContext() = %q; want %q", err, want)
+	}
+
+	close(release)
+	if err := g.WaitContext(context.Background()); err != nil {
+		t.Errorf("g.WaitContext() = %v; want nil", err)
+	}
+	if n := len(g.Running()); n != 0 {
+		t.Errorf("g.Running() reports %d tasks after Wait; want 0", n)
+	}
+}
+
+func TestDone(t *testing.T) {
+	g := new(errgroup.Group)
+	select {
+	case <-g.Done():
+	default:
+		t.Fatal("g.Done() of an empty group is not closed")
+	}
+
+	release := make(chan struct{})
+	g.Go(func() error {
+		<-release
+		return nil
+	})
+	done := g.Done()
+	select {
+	case <-done:
+		t.Fatal("g.Done() closed while a function is running")
+	case <-time.After(1 * time.Millisecond):
+	}
+	close(release)
+	<-done
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// recorder is an errgroup.Observer that records its calls.
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) record(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) TaskQueued(t errgroup.TaskInfo) { r.record("queued %s", t.Name) }
+
+func (r *recorder) TaskStarted(t errgroup.TaskInfo) {
+	if t.Started.IsZero() {
+		r.record("started %s without start time", t.Name)
+	}
+	r.record("started %s", t.Name)
+}
+
+func (r *recorder) TaskFinished(t errgroup.TaskInfo, err error, d time.Duration) {
+	if d < 0 {
+		r.record("finished %s with negative duration", t.Name)
+	}
+	r.record("finished %s: %v", t.Name, err)
+}
+
+func (r *recorder) GroupCanceled(cause error) { r.record("canceled: %v", cause) }
+
+func TestObserver(t *testing.T) {
+	g, _ := errgroup.WithContext(context.Background())
+	var rec recorder
+	g.SetObserver(&rec)
+	g.GoNamed("a", func() error { return nil })
+	g.Wait()
+	g.GoNamed("b", func() error { return errors.New("doomed") })
+	g.Wait()
+	if !g.TryGo(func() error { return nil }) {
+		t.Fatal("TryGo should succeed but got fail.")
+	}
+	g.Wait()
+
+	want := []string{
+		"queued a",
+		"started a",
+		"finished a: <nil>",
+		"queued b",
+		"started b",
+		"finished b: b: doomed",
+		"canceled: b: doomed",
+		"queued ",
+		"started ",
+		"finished : <nil>",
+	}
+	if !slices.Equal(rec.events, want) {
+		t.Errorf("observed events:\n%s\nwant:\n%s", strings.Join(rec.events, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// goroutineObserver is an errgroup.Observer that records the goroutine
+// from which TaskStarted and TaskFinished are called.
+type goroutineObserver struct {
+	started, finished chan string
+}
+
+func (o goroutineObserver) TaskQueued(errgroup.TaskInfo)  {}
+func (o goroutineObserver) TaskStarted(errgroup.TaskInfo) { o.started <- goroutine() }
+func (o goroutineObserver) TaskFinished(errgroup.TaskInfo, error, time.Duration) {
+	o.finished <- goroutine()
+}
+func (o goroutineObserver) GroupCanceled(error) {}
+
+// goroutine returns the first line of the calling goroutine's stack trace,
+// which identifies it.
+func goroutine() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	id, _, _ := strings.Cut(string(buf), " [")
+	return id
+}
+
+func TestObserverGoroutine(t *testing.T) {
+	g := new(errgroup.Group)
+	o := goroutineObserver{make(chan string, 1), make(chan string, 1)}
+	g.SetObserver(o)
+	ran := make(chan string, 1)
+	g.Go(func() error {
+		ran <- goroutine()
+		return nil
+	})
+	g.Wait()
+	caller, callee := goroutine(), <-ran
+	if callee == caller {
+		t.Fatalf("function called from %s, the goroutine calling Go", caller)
+	}
+	if started := <-o.started; started != callee {
+		t.Errorf("TaskStarted called from %s; want %s, which called the function", started, callee)
+	}
+	if finished := <-o.finished; finished != callee {
+		t.Errorf("TaskFinished called from %s; want %s, which called the function", finished, callee)
+	}
+}
+
+func TestStallHandler(t *testing.T) {
+	g := new(errgroup.Group)
+	var stalls atomic.Int32
+	release := make(chan struct{})
+	g.SetStallHandler(1*time.Millisecond, func(running []errgroup.TaskInfo) {
+		i := slices.IndexFunc(running, func(task errgroup.TaskInfo) bool { return task.Name == "stuck" })
+		if i < 0 {
+			t.Errorf("stall handler called with %v; want the stuck task included", running)
+			return
+		}
+		task := running[i]
+		if !strings.Contains(string(task.Stack), "TestStallHandler") {
+			t.Errorf("stalled task stack does not mention TestStallHandler:\n%s", task.Stack)
+		}
+		if elapsed := time.Since(task.Started); elapsed < 1*time.Millisecond {
+			t.Errorf("stalled task has been running for %v; want ≥ 1ms", elapsed)
+		}
+		if stalls.Add(1) == 3 {
+			close(release)
+		}
+	})
+	g.GoNamed("fast", func() error { return nil })
+	g.GoNamed("stuck", func() error {
+		<-release
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if n := stalls.Load(); n != 3 {
+		t.Errorf("stall handler called %d times; want 3", n)
+	}
+}
+
+func TestStallHandlerThreshold(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("SetStallHandler(0, h) did not panic")
+		}
+	}()
+	g := new(errgroup.Group)
+	g.SetStallHandler(0, func([]errgroup.TaskInfo) {})
+}
+
+func TestGoContext(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetLimit(1)
+	fail := make(chan struct{})
+	if err := g.GoContext(ctx, func() error {
+		<-fail
+		return errDoom
+	}); err != nil {
+		t.Fatalf("GoContext = %v; want nil", err)
+	}
+
+	// The group is full, so the next call blocks until the first function
+	// fails and cancels ctx.
+	close(fail)
+	called := false
+	if err := g.GoContext(ctx, func() error { called = true; return nil }); err != errDoom {
+		t.Errorf("GoContext after failure = %v; want %v", err, errDoom)
+	}
+	if err := g.Wait(); err != errDoom {
+		t.Errorf("g.Wait() = %v; want %v", err, errDoom)
+	}
+	if called {
+		t.Errorf("function passed to GoContext was called after ctx was done")
+	}
+}
+
+func TestGoWeighted(t *testing.T) {
+	const budget = 10
+
+	g := &errgroup.Group{}
+	g.SetWeighted(semaphore.NewWeighted(budget), budget)
+	var used int64
+	for i := 0; i <= 1<<8; i++ {
+		n := int64(1 + i%budget)
+		g.GoWeighted(n, func() error {
+			if u := atomic.AddInt64(&used, n); u > budget {
+				return fmt.Errorf("saw total weight %d active; want ≤ %d", u, budget)
+			}
+			time.Sleep(1 * time.Microsecond) // Give other goroutines a chance to increment used.
+			atomic.AddInt64(&used, -n)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []int64{-1, budget + 1} {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("GoWeighted(%d, f) with a semaphore of size %d did not panic", n, budget)
+				}
+			}()
+			g.GoWeighted(n, func() error { return nil })
+		}()
+	}
+}
+
+func TestGoWeightedSub(t *testing.T) {
+	g := new(errgroup.Group)
+	s := semaphore.NewWeighted(1)
+	g.SetWeighted(s, 1)
+	child, _ := g.Sub()
+
+	release := make(chan struct{})
+	child.GoWeighted(1, func() error {
+		<-release
+		return nil
+	})
+	if s.TryAcquire(1) {
+		t.Fatal("child.GoWeighted did not acquire its weight from the parent's semaphore")
+	}
+	close(release)
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if !s.TryAcquire(1) {
+		t.Error("child.GoWeighted did not release its weight")
+	}
+}
+
 func TestCancelCause(t *testing.T) {
 	errDoom := errors.New("group_test: doomed")
 
@@ -289,6 +909,197 @@ func TestCancelCause(t *testing.T) {
 	}
 }
 
+func TestCollectErrors(t *testing.T) {
+	err1 := errors.New("errgroup_test: 1")
+	err2 := errors.New("errgroup_test: 2")
+
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetCollectErrors(true)
+	g.SetLimit(1) // Run the functions in order.
+	g.Go(func() error { return err1 })
+	g.Go(func() error { return nil })
+	g.Go(func() error { return err2 })
+
+	err := g.Wait()
+	if !errors.Is(err, err1) || !errors.Is(err, err2) {
+		t.Errorf("g.Wait() = %v; want an error matching both %v and %v", err, err1, err2)
+	}
+	if want := err1.Error() + "\n" + err2.Error(); err == nil || err.Error() != want {
+		t.Errorf("g.Wait() = %q; want %q", err, want)
+	}
+	if cause := context.Cause(ctx); cause != err1 {
+		t.Errorf("context.Cause(ctx) = %v; want %v", cause, err1)
+	}
+
+	g = new(errgroup.Group)
+	g.SetCollectErrors(true)
+	g.Go(func() error { return nil })
+	if err := g.Wait(); err != nil {
+		t.Errorf("g.Wait() = %v; want nil", err)
+	}
+}
+
+func TestPanic(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+	for _, value := range []any{"boom", errDoom} {
+		g, ctx := errgroup.WithContext(context.Background())
+		g.SetRecoverPanics(true)
+		g.Go(func() error {
+			<-ctx.Done()
+			return nil
+		})
+		g.Go(func() error { panicInTask(value); return nil })
+
+		var p any
+		func() {
+			defer func() { p = recover() }()
+			g.Wait()
+		}()
+
+		pe, ok := p.(*errgroup.PanicError)
+		if !ok {
+			t.Fatalf("g.Wait() panicked with %T %v; want *errgroup.PanicError", p, p)
+		}
+		if pe.Value != value {
+			t.Errorf("PanicError.Value = %v; want %v", pe.Value, value)
+		}
+		if !strings.Contains(string(pe.Stack), "panicInTask") {
+			t.Errorf("PanicError.Stack does not mention panicInTask:\n%s", pe.Stack)
+		}
+		if err, _ := value.(error); err != nil && !errors.Is(pe, err) {
+			t.Errorf("errors.Is(%v, %v) = false; want true", pe, err)
+		}
+		if cause := context.Cause(ctx); cause != pe {
+			t.Errorf("context.Cause(ctx) = %v; want the PanicError", cause)
+		}
+	}
+}
+
+//go:noinline
+func panicInTask(v any) {
+	panic(v)
+}
+
+func TestGoexit(t *testing.T) {
+	g := new(errgroup.Group)
+	g.SetRecoverPanics(true)
+	g.Go(func() error {
+		runtime.Goexit()
+		return nil
+	})
+
+	returned := false
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		g.Wait()
+		returned = true
+	}()
+	<-exited
+	if returned {
+		t.Errorf("g.Wait() returned; want it to call runtime.Goexit")
+	}
+}
+
+func TestGoexitWithoutRecover(t *testing.T) {
+	g, ctx := errgroup.WithContext(context.Background())
+	g.Go(func() error {
+		runtime.Goexit()
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		t.Errorf("g.Wait() = %v; want nil", err)
+	}
+	if cause := context.Cause(ctx); cause != context.Canceled {
+		t.Errorf("context.Cause(ctx) = %v; want %v", cause, context.Canceled)
+	}
+	if s := g.Stats(); s.Active != 0 || s.Failed != 1 {
+		t.Errorf("g.Stats() = %+v; want 0 active and 1 failed", s)
+	}
+}
+
+func TestFailurePolicy(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	cases := []struct {
+		name   string
+		policy errgroup.FailurePolicy
+		errs   []error
+		cancel int // index of the error that cancels, or -1
+	}{
+		{"default", nil, []error{nil, errDoom, errDoom}, 1},
+		{"FailFast", errgroup.FailFast(), []error{nil, errDoom, errDoom}, 1},
+		{"NeverCancel", errgroup.NeverCancel(), []error{errDoom, errDoom, errDoom}, -1},
+		{"CancelAfter", errgroup.CancelAfter(2), []error{errDoom, nil, errDoom, errDoom}, 3},
+		{"CancelAboveRatio", errgroup.CancelAboveRatio(0.5, 4), []error{errDoom, errDoom, nil, nil, errDoom}, 4},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g, ctx := errgroup.WithContext(context.Background())
+			g.SetFailurePolicy(tc.policy)
+			g.SetCollectErrors(true)
+			g.SetLimit(1) // Run the functions in order.
+
+			canceledAt := -1
+			for i, err := range tc.errs {
+				err := fmt.Errorf("%d: %w", i, err)
+				if tc.errs[i] == nil {
+					err = nil
+				}
+				g.Go(func() error {
+					if canceledAt < 0 && ctx.Err() != nil {
+						canceledAt = i - 1
+					}
+					return err
+				})
+			}
+			g.Go(func() error {
+				if canceledAt < 0 && ctx.Err() != nil {
+					canceledAt = len(tc.errs) - 1
+				}
+				return nil
+			})
+			err := g.Wait()
+
+			if canceledAt != tc.cancel {
+				t.Errorf("canceled after function %d; want %d", canceledAt, tc.cancel)
+			}
+			if tc.cancel >= 0 {
+				if cause := context.Cause(ctx); cause == nil || !strings.HasPrefix(cause.Error(), fmt.Sprint(tc.cancel, ":")) {
+					t.Errorf("context.Cause(ctx) = %v; want error from function %d", cause, tc.cancel)
+				}
+			}
+			if !errors.Is(err, errDoom) {
+				t.Errorf("g.Wait() = %v; want it to report the failures", err)
+			}
+		})
+	}
+}
+
+func TestFailurePolicyPanic(t *testing.T) {
+	g, ctx := errgroup.WithContext(context.Background())
+	g.SetRecoverPanics(true)
+	g.SetFailurePolicy(errgroup.NeverCancel())
+	g.Go(func() error {
+		<-ctx.Done()
+		return nil
+	})
+	g.Go(func() error { panic("boom") })
+
+	var p any
+	func() {
+		defer func() { p = recover() }()
+		g.Wait()
+	}()
+	pe, ok := p.(*errgroup.PanicError)
+	if !ok {
+		t.Fatalf("g.Wait() panicked with %T %v; want *errgroup.PanicError", p, p)
+	}
+	if cause := context.Cause(ctx); cause != pe {
+		t.Errorf("context.Cause(ctx) = %v; want the PanicError", cause)
+	}
+}
+
 func BenchmarkGo(b *testing.B) {
 	fn := func() {}
 	g := &errgroup.Group{}
@@ -299,3 +1110,27 @@ func BenchmarkGo(b *testing.B) {
 	}
 	g.Wait()
 }
+
+func BenchmarkGoLimit(b *testing.B) {
+	fn := func() {}
+	g := &errgroup.Group{}
+	g.SetLimit(runtime.GOMAXPROCS(0))
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g.Go(func() error { fn(); return nil })
+	}
+	g.Wait()
+}
+
+func BenchmarkPool(b *testing.B) {
+	fn := func() {}
+	p := &errgroup.Pool{}
+	p.SetLimit(runtime.GOMAXPROCS(0))
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		p.Go(func() error { fn(); return nil })
+	}
+	p.Wait()
+}
@@ -0,0 +1,60 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrLostRace is the cause with which First cancels the Context of the
+// functions that did not succeed first.
+var ErrLostRace = errors.New("errgroup: another function succeeded first")
+
+// First calls each of fns in its own goroutine and returns the result of the
+// first one to succeed.
+//
+// The functions are passed a Context derived from ctx. Once one of them
+// succeeds, the Context is canceled with cause [ErrLostRace], so that the
+// others can stop early. First returns after all the functions have returned.
+//
+// If every function fails, First returns all their errors joined with
+// [errors.Join]. If a function panics, the Context is canceled and First
+// panics with a *[PanicError] once the others have returned.
+func First[T any](ctx context.Context, fns ...func(context.Context) (T, error)) (T, error) {
+	var zero T
+	if len(fns) == 0 {
+		return zero, errors.New("errgroup: First called with no functions")
+	}
+
+	g, ctx := WithContext(ctx)
+	g.SetRecoverPanics(true)
+	g.SetFailurePolicy(NeverCancel())
+	g.SetCollectErrors(true)
+	var (
+		once   sync.Once
+		won    bool
+		result T
+	)
+	for _, fn := range fns {
+		g.Go(func() error {
+			v, err := fn(ctx)
+			if err != nil {
+				return err
+			}
+			once.Do(func() {
+				won, result = true, v
+				g.cancel(ErrLostRace)
+			})
+			return nil
+		})
+	}
+	err := g.Wait()
+	if won {
+		return result, nil
+	}
+	return zero, err
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func ExampleFirst() {
+	mirror := func(name string, delay time.Duration) func(context.Context) (string, error) {
+		return func(ctx context.Context) (string, error) {
+			select {
+			case <-time.After(delay):
+				return name, nil
+			case <-ctx.Done():
+				return "", context.Cause(ctx)
+			}
+		}
+	}
+
+	fastest, err := errgroup.First(context.Background(),
+		mirror("slow mirror", 1*time.Second),
+		mirror("fast mirror", 1*time.Millisecond),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(fastest)
+
+	// Output:
+	// fast mirror
+}
+
+func TestFirstCancelsLosers(t *testing.T) {
+	loserCause := make(chan error, 1)
+	got, err := errgroup.First(context.Background(),
+		func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			loserCause <- context.Cause(ctx)
+			return 0, ctx.Err()
+		},
+		func(ctx context.Context) (int, error) {
+			return 42, nil
+		},
+	)
+	if got != 42 || err != nil {
+		t.Errorf("First() = %v, %v; want 42, nil", got, err)
+	}
+	if cause := <-loserCause; cause != errgroup.ErrLostRace {
+		t.Errorf("losing function saw cause %v; want %v", cause, errgroup.ErrLostRace)
+	}
+}
+
+func TestFirstAllFail(t *testing.T) {
+	err1 := errors.New("errgroup_test: 1")
+	err2 := errors.New("errgroup_test: 2")
+	got, err := errgroup.First(context.Background(),
+		func(context.Context) (string, error) { return "", err1 },
+		func(context.Context) (string, error) { return "", err2 },
+	)
+	if got != "" || !errors.Is(err, err1) || !errors.Is(err, err2) {
+		t.Errorf("First() = %q, %v; want \"\" and an error matching %v and %v", got, err, err1, err2)
+	}
+
+	if _, err := errgroup.First[int](context.Background()); err == nil {
+		t.Errorf("First() with no functions returned a nil error")
+	}
+}
+
+func TestFirstPanic(t *testing.T) {
+	var p any
+	func() {
+		defer func() { p = recover() }()
+		errgroup.First(context.Background(),
+			func(context.Context) (int, error) { panic("boom") },
+			func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				return 0, context.Cause(ctx)
+			},
+		)
+	}()
+	if pe, ok := p.(*errgroup.PanicError); !ok || pe.Value != "boom" {
+		t.Errorf("First() panicked with %v; want a PanicError for \"boom\"", p)
+	}
+}
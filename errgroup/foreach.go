@@ -0,0 +1,83 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"iter"
+)
+
+// ForEach calls fn for each value produced by seq, each call in its own
+// goroutine, with at most limit calls active at a time. A limit of zero or
+// less means no limit.
+//
+// The Context passed to fn is derived from ctx and canceled the first time a
+// call to fn returns a non-nil error. ForEach only pulls the next value from
+// seq once a call to fn can be started for it, and stops pulling values as
+// soon as the Context is canceled, so no value is pulled and then dropped.
+//
+// ForEach returns after all calls to fn have returned. It returns the first
+// non-nil error from fn, if any, or else the cause of ctx's cancellation if
+// ctx was done before every value was processed.
+func ForEach[T any](ctx context.Context, limit int, seq iter.Seq[T], fn func(context.Context, T) error) error {
+	g, ctx := WithContext(ctx)
+	if limit > 0 {
+		g.SetLimit(limit)
+	}
+	// reserve acquires a slot for the next call to fn, before its value is
+	// pulled from seq.
+	reserve := func() error {
+		if !g.acquire(ctx.Done(), 0) {
+			return context.Cause(ctx)
+		}
+		if ctx.Err() != nil {
+			g.limiter().release()
+			return context.Cause(ctx)
+		}
+		return nil
+	}
+	err := reserve()
+	if err == nil {
+		for v := range seq {
+			g.start(func() error { return fn(ctx, v) }, g.newTask(""))
+			if err = reserve(); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			// Release the slot reserved for a value seq did not produce.
+			g.limiter().release()
+		}
+	}
+	if werr := g.Wait(); werr != nil {
+		return werr
+	}
+	return err
+}
+
+// Map calls fn for each element of items, as ForEach does, and returns the
+// results in the same order as items.
+//
+// If any call to fn fails, or ctx is done before every element was
+// processed, Map returns a nil slice and the error that ForEach would return.
+func Map[T, R any](ctx context.Context, limit int, items []T, fn func(context.Context, T) (R, error)) ([]R, error) {
+	out := make([]R, len(items))
+	indices := func(yield func(int) bool) {
+		for i := range items {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	err := ForEach(ctx, limit, indices, func(ctx context.Context, i int) error {
+		r, err := fn(ctx, items[i])
+		out[i] = r
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
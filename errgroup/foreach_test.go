@@ -0,0 +1,110 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func ExampleMap() {
+	words := []string{"alpha", "beta", "gamma"}
+	upper, err := errgroup.Map(context.Background(), 2, words, func(_ context.Context, w string) (string, error) {
+		return strings.ToUpper(w), nil
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(upper)
+
+	// Output:
+	// [ALPHA BETA GAMMA]
+}
+
+func TestForEachStopsAfterError(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	var pulled []int
+	naturals := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			pulled = append(pulled, i)
+			if !yield(i) {
+				return
+			}
+		}
+	}
+	err := errgroup.ForEach(context.Background(), 1, naturals, func(ctx context.Context, i int) error {
+		if i == 2 {
+			return errDoom
+		}
+		return nil
+	})
+	if err != errDoom {
+		t.Errorf("ForEach() = %v; want %v", err, errDoom)
+	}
+	if want := []int{0, 1, 2}; !slices.Equal(pulled, want) {
+		t.Errorf("ForEach pulled %v; want %v", pulled, want)
+	}
+}
+
+func TestForEachCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := errgroup.ForEach(ctx, 1, slices.Values([]int{1, 2, 3}), func(ctx context.Context, i int) error {
+		if i == 1 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("ForEach() = %v; want %v", err, context.Canceled)
+	}
+}
+
+func TestMapLimit(t *testing.T) {
+	const limit = 3
+
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+	var active int32
+	got, err := errgroup.Map(context.Background(), limit, items, func(_ context.Context, i int) (int, error) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		if n > limit {
+			return 0, fmt.Errorf("saw %d active goroutines; want ≤ %d", n, limit)
+		}
+		time.Sleep(1 * time.Microsecond) // Give other goroutines a chance to increment active.
+		return i * i, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, v := range got {
+		if v != i*i {
+			t.Fatalf("Map()[%d] = %d; want %d", i, v, i*i)
+		}
+	}
+
+	errDoom := errors.New("group_test: doomed")
+	got, err = errgroup.Map(context.Background(), limit, items, func(_ context.Context, i int) (int, error) {
+		if i == 50 {
+			return 0, errDoom
+		}
+		return i, nil
+	})
+	if err != errDoom || got != nil {
+		t.Errorf("Map() = %v, %v; want nil, %v", got, err, errDoom)
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSkipped is wrapped by the errors Graph.Run reports for tasks that were
+// not run because a task they depend on failed or was skipped.
+var ErrSkipped = errors.New("errgroup: skipped")
+
+// A Graph runs a set of named tasks, each as soon as all the tasks it depends
+// on have succeeded.
+//
+// A zero Graph is valid, has no tasks and no limit on the number of active
+// goroutines.
+type Graph struct {
+	tasks   []*graphTask
+	byName  map[string]*graphTask
+	limit   int
+	limited bool
+}
+
+type graphTask struct {
+	name string
+	f    func(context.Context) error
+	deps []string
+
+	dependents []*graphTask
+	pending    int  // number of deps not yet succeeded
+	resolved   bool // whether the task has finished or been skipped
+}
+
+// Add adds a task with the given name that runs f once every task named in
+// deps has succeeded. The dependencies may be added before or after the task
+// that names them.
+func (gr *Graph) Add(name string, f func(ctx context.Context) error, deps ...string) {
+	gr.tasks = append(gr.tasks, &graphTask{name: name, f: f, deps: deps})
+}
+
+// SetLimit limits the number of tasks running at once to at most n, as
+// [Group.SetLimit] does. A negative value indicates no limit.
+func (gr *Graph) SetLimit(n int) {
+	gr.limit, gr.limited = n, n >= 0
+}
+
+// Run runs the tasks of the graph and returns after all the tasks it started
+// have returned. Each task is called in its own goroutine with a Context
+// derived from ctx, and carries its name as in [Group.GoNamed].
+//
+// Before running anything, Run checks that every dependency names a task in
+// the graph, that task names are unique, and that there are no dependency
+// cycles, and reports an error if not.
+//
+// A failing task does not stop tasks that do not depend on it. Tasks that
+// depend on it, directly or indirectly, are skipped, as are tasks that have
+// not started when ctx is done. Run returns the errors of the failed tasks and
+// an error wrapping [ErrSkipped] for each skipped task, joined with
+// [errors.Join], or nil if every task succeeded. A task that panics fails
+// like one that returns an error, and Run then panics with a *[PanicError]
+// once the tasks it started have returned.
+func (gr *Graph) Run(ctx context.Context) error {
+	if err := gr.check(); err != nil {
+		return err
+	}
+
+	g, gctx := WithContext(ctx)
+	if gr.limited {
+		g.SetLimit(gr.limit)
+	}
+	g.SetRecoverPanics(true)
+	g.SetFailurePolicy(NeverCancel())
+
+	type result struct {
+		t   *graphTask
+		err error
+	}
+	results := make(chan result, len(gr.tasks)) // never blocks a task
+	var (
+		errs      []error
+		ready     []*graphTask
+		remaining = len(gr.tasks)
+		running   = 0
+	)
+	var skip func(t *graphTask, reason string)
+	skip = func(t *graphTask, reason string) {
+		for _, dep := range t.dependents {
+			if !dep.resolved {
+				dep.resolved = true
+				remaining--
+				errs = append(errs, fmt.Errorf("%s: %w: %s", dep.name, ErrSkipped, reason))
+				skip(dep, "dependency "+dep.name+" was skipped")
+			}
+		}
+	}
+	for _, t := range gr.tasks {
+		if t.pending == 0 {
+			ready = append(ready, t)
+		}
+	}
+
+	for remaining > 0 {
+		for len(ready) > 0 {
+			t := ready[0]
+			ready = ready[1:]
+			f := g.named(t.name, func() error { return t.f(gctx) })
+			err := g.GoContext(gctx, func() (err error) {
+				returned := false
+				defer func() {
+					if !returned {
+						// The panic is re-raised by g.Wait.
+						err = fmt.Errorf("%s: panicked", t.name)
+					}
+					results <- result{t, err}
+				}()
+				err = f()
+				returned = true
+				return err
+			})
+			if err != nil {
+				t.resolved = true
+				remaining--
+				errs = append(errs, fmt.Errorf("%s: %w: %v", t.name, ErrSkipped, err))
+				skip(t, "dependency "+t.name+" was skipped")
+				continue
+			}
+			running++
+		}
+		if running == 0 {
+			break
+		}
+		r := <-results
+		running--
+		r.t.resolved = true
+		remaining--
+		if r.err != nil {
+			errs = append(errs, r.err)
+			skip(r.t, "dependency "+r.t.name+" failed")
+			continue
+		}
+		for _, dep := range r.t.dependents {
+			dep.pending--
+			if dep.pending == 0 && !dep.resolved {
+				ready = append(ready, dep)
+			}
+		}
+	}
+	g.Wait()
+	return errors.Join(errs...)
+}
+
+// check validates the graph and prepares it to run.
+func (gr *Graph) check() error {
+	gr.byName = make(map[string]*graphTask, len(gr.tasks))
+	for _, t := range gr.tasks {
+		if _, ok := gr.byName[t.name]; ok {
+			return fmt.Errorf("errgroup: duplicate task %q", t.name)
+		}
+		gr.byName[t.name] = t
+		t.dependents, t.pending, t.resolved = nil, len(t.deps), false
+	}
+	for _, t := range gr.tasks {
+		for _, name := range t.deps {
+			dep, ok := gr.byName[name]
+			if !ok {
+				return fmt.Errorf("errgroup: task %q depends on unknown task %q", t.name, name)
+			}
+			dep.dependents = append(dep.dependents, t)
+		}
+	}
+
+	// Look for a cycle with a depth-first search.
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*graphTask]int, len(gr.tasks))
+	var path []string
+	var visit func(t *graphTask) error
+	visit = func(t *graphTask) error {
+		switch state[t] {
+		case visiting:
+			i := 0
+			for path[i] != t.name {
+				i++
+			}
+			cycle := append(path[i:], t.name)
+			return fmt.Errorf("errgroup: dependency cycle: %s", strings.Join(cycle, " -> "))
+		case visited:
+			return nil
+		}
+		state[t] = visiting
+		path = append(path, t.name)
+		for _, name := range t.deps {
+			if err := visit(gr.byName[name]); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[t] = visited
+		return nil
+	}
+	for _, t := range gr.tasks {
+		if err := visit(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
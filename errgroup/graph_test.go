@@ -0,0 +1,159 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func ExampleGraph() {
+	var mu sync.Mutex
+	step := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			fmt.Println(name)
+			return nil
+		}
+	}
+
+	// Run A and B in parallel, then C once both succeed, then D.
+	var gr errgroup.Graph
+	gr.Add("D", step("D"), "C")
+	gr.Add("C", step("C"), "A", "B")
+	gr.Add("A", step("A"))
+	gr.Add("B", step("B"))
+	gr.SetLimit(1) // Keep the output stable.
+	if err := gr.Run(context.Background()); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// A
+	// B
+	// C
+	// D
+}
+
+func TestGraphOrder(t *testing.T) {
+	var (
+		gr       errgroup.Graph
+		mu       sync.Mutex
+		finished = map[string]bool{}
+	)
+	deps := map[string][]string{
+		"a": nil,
+		"b": nil,
+		"c": {"a", "b"},
+		"d": {"c"},
+		"e": {"a"},
+		"f": {"d", "e"},
+	}
+	for name, deps := range deps {
+		gr.Add(name, func(context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, dep := range deps {
+				if !finished[dep] {
+					return fmt.Errorf("started before dependency %s finished", dep)
+				}
+			}
+			finished[name] = true
+			return nil
+		}, deps...)
+	}
+	gr.SetLimit(2)
+	if err := gr.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(finished) != len(deps) {
+		t.Errorf("ran %d tasks; want %d", len(finished), len(deps))
+	}
+}
+
+func TestGraphFailureSkipsDownstream(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	var (
+		gr  errgroup.Graph
+		mu  sync.Mutex
+		ran []string
+	)
+	task := func(name string, err error) func(context.Context) error {
+		return func(context.Context) error {
+			mu.Lock()
+			ran = append(ran, name)
+			mu.Unlock()
+			return err
+		}
+	}
+	gr.Add("a", task("a", errDoom))
+	gr.Add("b", task("b", nil), "a")
+	gr.Add("c", task("c", nil), "b")
+	gr.Add("other", task("other", nil))
+
+	err := gr.Run(context.Background())
+	slices.Sort(ran)
+	if want := []string{"a", "other"}; !slices.Equal(ran, want) {
+		t.Errorf("ran %v; want %v", ran, want)
+	}
+	if !errors.Is(err, errDoom) || !errors.Is(err, errgroup.ErrSkipped) {
+		t.Errorf("gr.Run() = %v; want an error matching %v and %v", err, errDoom, errgroup.ErrSkipped)
+	}
+	want := "a: group_test: doomed\n" +
+		"b: errgroup: skipped: dependency a failed\n" +
+		"c: errgroup: skipped: dependency b was skipped"
+	if err == nil || err.Error() != want {
+		t.Errorf("gr.Run() = %q; want %q", err, want)
+	}
+}
+
+func TestGraphInvalid(t *testing.T) {
+	cases := []struct {
+		name  string
+		tasks [][]string // name, then deps
+		want  string
+	}{
+		{"cycle", [][]string{{"a", "c"}, {"b", "a"}, {"c", "b"}, {"d"}}, "errgroup: dependency cycle: a -> c -> b -> a"},
+		{"self", [][]string{{"a", "a"}}, "errgroup: dependency cycle: a -> a"},
+		{"unknown", [][]string{{"a", "x"}}, `errgroup: task "a" depends on unknown task "x"`},
+		{"duplicate", [][]string{{"a"}, {"a"}}, `errgroup: duplicate task "a"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gr errgroup.Graph
+			ran := false
+			for _, task := range tc.tasks {
+				gr.Add(task[0], func(context.Context) error { ran = true; return nil }, task[1:]...)
+			}
+			err := gr.Run(context.Background())
+			if err == nil || err.Error() != tc.want {
+				t.Errorf("gr.Run() = %v; want %s", err, tc.want)
+			}
+			if ran {
+				t.Errorf("gr.Run() ran a task of an invalid graph")
+			}
+		})
+	}
+}
+
+func TestGraphCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var gr errgroup.Graph
+	gr.Add("a", func(context.Context) error { cancel(); return nil })
+	gr.Add("b", func(context.Context) error { return nil }, "a")
+	err := gr.Run(ctx)
+	if !errors.Is(err, errgroup.ErrSkipped) || !strings.Contains(err.Error(), context.Canceled.Error()) {
+		t.Errorf("gr.Run() = %v; want b skipped because ctx was canceled", err)
+	}
+}
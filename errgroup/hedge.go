@@ -0,0 +1,79 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Hedge calls fn, and calls it again in a new goroutine each time delay passes
+// without any call having succeeded, up to maxAttempts calls in total. A call
+// that fails is replaced right away rather than after delay. Hedging bounds
+// the latency added by a slow attempt, such as a request to an overloaded
+// replica, at the cost of extra work.
+//
+// Hedge returns the result of the first call to succeed. The calls are passed
+// a Context derived from ctx, which is canceled as soon as one call succeeds;
+// the cause of the cancellation then matches [ErrLostRace] according to
+// [errors.Is], so that losing attempts can tell they were superseded.
+// Hedge returns after all the calls it made have returned.
+//
+// If every call fails, Hedge returns all their errors joined with
+// [errors.Join]. A call that panics cancels the Context and stops further
+// attempts; Hedge then panics with a *[PanicError] once the calls already
+// made have returned. A maxAttempts of less than 1 is treated as 1.
+func Hedge[T any](ctx context.Context, delay time.Duration, maxAttempts int, fn func(context.Context) (T, error)) (T, error) {
+	maxAttempts = max(maxAttempts, 1)
+
+	g, ctx := WithContext(ctx)
+	g.SetRecoverPanics(true)
+	g.SetFailurePolicy(NeverCancel())
+	g.SetCollectErrors(true)
+	var (
+		once   sync.Once
+		won    bool
+		result T
+	)
+	failed := make(chan struct{}, maxAttempts)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+launch:
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-timer.C:
+			case <-failed:
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				// An attempt succeeded or panicked, or ctx was canceled.
+				break launch
+			}
+			timer.Reset(delay)
+		}
+		g.Go(func() error {
+			v, err := fn(ctx)
+			if err != nil {
+				failed <- struct{}{}
+				return err
+			}
+			once.Do(func() {
+				won, result = true, v
+				g.cancel(fmt.Errorf("%w: attempt %d succeeded", ErrLostRace, attempt))
+			})
+			return nil
+		})
+	}
+	err := g.Wait()
+	if won {
+		return result, nil
+	}
+	var zero T
+	return zero, err
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func TestHedgeSlowFirstAttempt(t *testing.T) {
+	var attempts atomic.Int32
+	loserCause := make(chan error, 1)
+	got, err := errgroup.Hedge(context.Background(), 1*time.Millisecond, 3, func(ctx context.Context) (int, error) {
+		n := attempts.Add(1)
+		if n == 2 {
+			return int(n), nil
+		}
+		// The other attempts are stuck until they lose. Under load, a
+		// third attempt may start before the second one has won.
+		<-ctx.Done()
+		if n == 1 {
+			loserCause <- context.Cause(ctx)
+		}
+		return 0, ctx.Err()
+	})
+	if got != 2 || err != nil {
+		t.Errorf("Hedge() = %v, %v; want 2, nil", got, err)
+	}
+	if n := attempts.Load(); n < 2 {
+		t.Errorf("Hedge made %d attempts; want at least 2", n)
+	}
+	cause := <-loserCause
+	if !errors.Is(cause, errgroup.ErrLostRace) {
+		t.Errorf("losing attempt saw cause %v; want an error matching %v", cause, errgroup.ErrLostRace)
+	}
+}
+
+func TestHedgeAllFail(t *testing.T) {
+	var attempts atomic.Int32
+	start := time.Now()
+	_, err := errgroup.Hedge(context.Background(), 1*time.Hour, 3, func(ctx context.Context) (int, error) {
+		return 0, fmt.Errorf("attempt %d failed", attempts.Add(1))
+	})
+	if elapsed := time.Since(start); elapsed > 1*time.Minute {
+		t.Errorf("Hedge took %v; want failed attempts to be replaced without waiting for delay", elapsed)
+	}
+	for i := 1; i <= 3; i++ {
+		if want := fmt.Sprintf("attempt %d failed", i); err == nil || !slices.Contains(strings.Split(err.Error(), "\n"), want) {
+			t.Errorf("Hedge() error = %v; want it to include %q", err, want)
+		}
+	}
+}
+
+func TestHedgePanic(t *testing.T) {
+	var attempts atomic.Int32
+	var p any
+	func() {
+		defer func() { p = recover() }()
+		errgroup.Hedge(context.Background(), 1*time.Hour, 3, func(ctx context.Context) (int, error) {
+			attempts.Add(1)
+			panic("boom")
+		})
+	}()
+	if pe, ok := p.(*errgroup.PanicError); !ok || pe.Value != "boom" {
+		t.Errorf("Hedge() panicked with %v; want a PanicError for \"boom\"", p)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("Hedge made %d attempts; want a panic to stop further attempts", n)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// A Pipeline is a sequence of stages connected by bounded channels, each stage
+// running its own goroutines. The first error in any stage cancels every
+// stage, and is returned by Wait.
+//
+// A Pipeline is built with Source, Transform and Sink, in that order.
+type Pipeline struct {
+	g   *Group
+	ctx context.Context
+}
+
+// NewPipeline returns a new Pipeline and an associated Context derived from
+// ctx, which is canceled the first time a stage fails or the first time Wait
+// returns, whichever occurs first.
+func NewPipeline(ctx context.Context) (*Pipeline, context.Context) {
+	g, ctx := WithContext(ctx)
+	return &Pipeline{g: g, ctx: ctx}, ctx
+}
+
+// Wait blocks until every stage has finished, then returns the first non-nil
+// error (if any) from them.
+func (p *Pipeline) Wait() error {
+	return p.g.Wait()
+}
+
+// A Stage is a stage of a Pipeline producing values of type T.
+type Stage[T any] struct {
+	p   *Pipeline
+	out chan T
+}
+
+// StageOptions configures a stage added by Transform or Sink.
+type StageOptions struct {
+	// Workers is the number of goroutines processing values concurrently.
+	// Values less than 1 are treated as 1.
+	Workers int
+
+	// Buffer is the capacity of the channel carrying the stage's output to
+	// the next stage. It is ignored by Sink.
+	Buffer int
+
+	// Ordered makes the stage emit its output in the order of its input,
+	// at the cost of holding back results that finish early. It is ignored
+	// by Sink.
+	Ordered bool
+}
+
+// send sends v on ch, giving up when ctx is done.
+func send[T any](ctx context.Context, ch chan<- T, v T) error {
+	select {
+	case ch <- v:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+// Source adds the first stage of p, which calls gen in a new goroutine.
+// gen produces values by calling emit, which blocks while the output channel,
+// of the given capacity, is full, and fails once the pipeline is canceled.
+func Source[T any](p *Pipeline, buffer int, gen func(ctx context.Context, emit func(T) error) error) *Stage[T] {
+	out := make(chan T, buffer)
+	p.g.Go(func() error {
+		defer close(out)
+		return gen(p.ctx, func(v T) error { return send(p.ctx, out, v) })
+	})
+	return &Stage[T]{p: p, out: out}
+}
+
+// Transform adds a stage after in that calls fn for each of its values and
+// passes the results on to the next stage.
+func Transform[In, Out any](in *Stage[In], opts StageOptions, fn func(context.Context, In) (Out, error)) *Stage[Out] {
+	p := in.p
+	workers := max(opts.Workers, 1)
+	out := make(chan Out, opts.Buffer)
+	if opts.Ordered {
+		transformOrdered(p, in.out, out, workers, fn)
+		return &Stage[Out]{p: p, out: out}
+	}
+
+	// The last worker to return closes out.
+	var active atomic.Int32
+	active.Store(int32(workers))
+	for range workers {
+		p.g.Go(func() error {
+			defer func() {
+				if active.Add(-1) == 0 {
+					close(out)
+				}
+			}()
+			for v := range in.out {
+				r, err := fn(p.ctx, v)
+				if err != nil {
+					return err
+				}
+				if err := send(p.ctx, out, r); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	return &Stage[Out]{p: p, out: out}
+}
+
+// transformOrdered is Transform with opts.Ordered set.
+//
+// A dispatcher hands each input value to the workers along with a channel for
+// its result, and queues the result channels in input order, at most workers
+// of them at a time. A collector forwards the results in queue order.
+func transformOrdered[In, Out any](p *Pipeline, in <-chan In, out chan<- Out, workers int, fn func(context.Context, In) (Out, error)) {
+	type job struct {
+		v   In
+		res chan Out
+	}
+	jobs := make(chan job)
+	pending := make(chan chan Out, workers)
+
+	p.g.Go(func() error {
+		defer close(jobs)
+		defer close(pending)
+		for v := range in {
+			res := make(chan Out, 1) // never blocks a worker
+			if err := send(p.ctx, pending, res); err != nil {
+				return err
+			}
+			if err := send(p.ctx, jobs, job{v, res}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	for range workers {
+		p.g.Go(func() error {
+			for j := range jobs {
+				r, err := fn(p.ctx, j.v)
+				if err != nil {
+					return err
+				}
+				j.res <- r
+			}
+			return nil
+		})
+	}
+	p.g.Go(func() error {
+		defer close(out)
+		for res := range pending {
+			var r Out
+			select {
+			case r = <-res:
+			case <-p.ctx.Done():
+				return context.Cause(p.ctx)
+			}
+			if err := send(p.ctx, out, r); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Sink adds the last stage after in, which calls fn for each of its values.
+func Sink[T any](in *Stage[T], opts StageOptions, fn func(context.Context, T) error) {
+	p := in.p
+	for range max(opts.Workers, 1) {
+		p.g.Go(func() error {
+			for v := range in.out {
+				if err := fn(p.ctx, v); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+}
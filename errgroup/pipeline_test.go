@@ -0,0 +1,184 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Pipeline demonstrates MD5All from errgroup_example_md5all_test.go written as
+// a Pipeline, without wiring the channels and goroutines by hand.
+func ExamplePipeline() {
+	m, err := PipelineMD5All(context.Background(), ".")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for k, sum := range m {
+		fmt.Printf("%s:\t%x\n", k, sum)
+	}
+}
+
+// PipelineMD5All reads all the files in the file tree rooted at root and
+// returns a map from file path to the MD5 sum of the file's contents.
+func PipelineMD5All(ctx context.Context, root string) (map[string][md5.Size]byte, error) {
+	p, _ := errgroup.NewPipeline(ctx)
+	paths := errgroup.Source(p, 0, func(ctx context.Context, emit func(string) error) error {
+		return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() {
+				return nil
+			}
+			return emit(path)
+		})
+	})
+	sums := errgroup.Transform(paths, errgroup.StageOptions{Workers: 20}, func(_ context.Context, path string) (result, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return result{}, err
+		}
+		return result{path, md5.Sum(data)}, nil
+	})
+	m := make(map[string][md5.Size]byte)
+	errgroup.Sink(sums, errgroup.StageOptions{}, func(_ context.Context, r result) error {
+		m[r.path] = r.sum
+		return nil
+	})
+	if err := p.Wait(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// count returns a Source emitting 0 through n-1.
+func count(p *errgroup.Pipeline, n int) *errgroup.Stage[int] {
+	return errgroup.Source(p, 0, func(_ context.Context, emit func(int) error) error {
+		for i := range n {
+			if err := emit(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func TestPipelineOrdered(t *testing.T) {
+	const n = 100
+	p, _ := errgroup.NewPipeline(context.Background())
+	squares := errgroup.Transform(count(p, n), errgroup.StageOptions{Workers: 4, Ordered: true}, func(_ context.Context, i int) (int, error) {
+		// Finish in roughly reverse order within each batch of workers.
+		time.Sleep(time.Duration(n-i) * time.Microsecond)
+		return i * i, nil
+	})
+	var got []int
+	errgroup.Sink(squares, errgroup.StageOptions{}, func(_ context.Context, v int) error {
+		got = append(got, v)
+		return nil
+	})
+	if err := p.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	want := make([]int, n)
+	for i := range want {
+		want[i] = i * i
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Pipeline output = %v; want %v", got, want)
+	}
+}
+
+func TestPipelineWorkers(t *testing.T) {
+	const workers = 3
+	for _, ordered := range []bool{false, true} {
+		t.Run(fmt.Sprint("ordered=", ordered), func(t *testing.T) {
+			var active, peak atomic.Int32
+			p, _ := errgroup.NewPipeline(context.Background())
+			s := errgroup.Transform(count(p, 50), errgroup.StageOptions{Workers: workers, Ordered: ordered}, func(_ context.Context, i int) (int, error) {
+				n := active.Add(1)
+				defer active.Add(-1)
+				for {
+					old := peak.Load()
+					if n <= old || peak.CompareAndSwap(old, n) {
+						break
+					}
+				}
+				time.Sleep(100 * time.Microsecond)
+				return i, nil
+			})
+			var sum int
+			errgroup.Sink(s, errgroup.StageOptions{}, func(_ context.Context, v int) error {
+				sum += v
+				return nil
+			})
+			if err := p.Wait(); err != nil {
+				t.Fatal(err)
+			}
+			if want := 50 * 49 / 2; sum != want {
+				t.Errorf("sum of outputs = %d; want %d", sum, want)
+			}
+			if got := peak.Load(); got > workers {
+				t.Errorf("saw %d active workers; want ≤ %d", got, workers)
+			}
+		})
+	}
+}
+
+func TestPipelineError(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+	for _, ordered := range []bool{false, true} {
+		t.Run(fmt.Sprint("ordered=", ordered), func(t *testing.T) {
+			p, ctx := errgroup.NewPipeline(context.Background())
+			// An endless source only stops once the pipeline is canceled.
+			naturals := errgroup.Source(p, 1, func(_ context.Context, emit func(int) error) error {
+				for i := 0; ; i++ {
+					if err := emit(i); err != nil {
+						return err
+					}
+				}
+			})
+			s := errgroup.Transform(naturals, errgroup.StageOptions{Workers: 2, Buffer: 1, Ordered: ordered}, func(_ context.Context, i int) (int, error) {
+				if i == 10 {
+					return 0, errDoom
+				}
+				return i, nil
+			})
+			errgroup.Sink(s, errgroup.StageOptions{Workers: 2}, func(context.Context, int) error { return nil })
+			if err := p.Wait(); err != errDoom {
+				t.Errorf("p.Wait() = %v; want %v", err, errDoom)
+			}
+			if ctx.Err() == nil {
+				t.Error("pipeline context not canceled after a stage failed")
+			}
+		})
+	}
+}
+
+func TestPipelineSinkError(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+	p, _ := errgroup.NewPipeline(context.Background())
+	errgroup.Sink(count(p, 100), errgroup.StageOptions{}, func(_ context.Context, i int) error {
+		if i == 5 {
+			return errDoom
+		}
+		return nil
+	})
+	if err := p.Wait(); err != errDoom {
+		t.Errorf("p.Wait() = %v; want %v", err, errDoom)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// poolQueueLen is the number of functions a Pool holds for its workers
+// before Go blocks. Letting a worker pick up its next function without
+// waiting for the caller of Go is what makes a Pool cheaper than a Group.
+const poolQueueLen = 256
+
+// A Pool is like a Group, but runs its functions on long-lived worker
+// goroutines pulling from a queue, rather than starting a new goroutine
+// for each function. It suits large numbers of short functions, for which
+// starting goroutines dominates the cost.
+//
+// Errors, panics and cancellation behave as they do in a Group.
+// Wait stops the workers once all functions have returned.
+//
+// A zero Pool is valid, runs up to [runtime.GOMAXPROCS] workers,
+// and does not cancel on error.
+type Pool struct {
+	g Group
+
+	once    sync.Once
+	queue   chan poolTask  // functions waiting for a worker
+	pending sync.WaitGroup // functions passed to Go that have not returned
+	full    atomic.Bool    // whether all the workers have been started
+
+	mu      sync.Mutex // protects the fields below
+	limited bool
+	limit   int           // see SetLimit
+	n       int           // number of workers
+	quit    chan struct{} // closed by Wait to stop the workers
+	workers sync.WaitGroup
+}
+
+// A poolTask is a function passed to Pool.Go, with its record, if any.
+type poolTask struct {
+	f func() error
+	t *task
+}
+
+// PoolWithContext returns a new Pool and an associated Context derived from
+// ctx.
+//
+// The derived Context is canceled the first time a function passed to Go
+// returns a non-nil error or the first time Wait returns, whichever occurs
+// first.
+func PoolWithContext(ctx context.Context) (*Pool, context.Context) {
+	p := new(Pool)
+	return p, p.g.withContext(ctx)
+}
+
+// Go queues the given function to be called by one of the pool's workers,
+// starting a new worker if fewer than the limit are running.
+//
+// Unlike [Group.Go], Go returns once f is queued, without waiting for it to
+// start; it only blocks while the queue is full. Otherwise it behaves like
+// Group.Go: the first function to return a non-nil error cancels the
+// associated Context, if any, and the error is returned by Wait.
+func (p *Pool) Go(f func() error) {
+	p.once.Do(func() { p.queue = make(chan poolTask, poolQueueLen) })
+	if !p.full.Load() {
+		p.spawn()
+	}
+	t := poolTask{f, p.g.newTask("")}
+	p.g.queued(t.t)
+	p.pending.Add(1)
+	p.g.waiting.Add(1)
+	p.queue <- t
+}
+
+// spawn starts another worker, unless all of them are running.
+func (p *Pool) spawn() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	limit := p.limit
+	if !p.limited {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	if p.n >= limit {
+		p.full.Store(true)
+		return
+	}
+	p.n++
+	if p.quit == nil {
+		p.quit = make(chan struct{})
+	}
+	p.workers.Add(1)
+	go p.work(p.quit)
+}
+
+// work calls the queued functions until quit is closed.
+func (p *Pool) work(quit <-chan struct{}) {
+	normalReturn := false
+	defer func() {
+		if !normalReturn {
+			// A function called runtime.Goexit, which ends this
+			// goroutine. Start a replacement so that the functions
+			// still queued are run.
+			go p.work(quit)
+			return
+		}
+		p.workers.Done()
+	}()
+
+	for {
+		select {
+		case t := <-p.queue:
+			p.g.waiting.Add(-1)
+			p.exec(t)
+		case <-quit:
+			normalReturn = true
+			return
+		}
+	}
+}
+
+// exec calls t.f as a member of the group.
+func (p *Pool) exec(t poolTask) {
+	defer p.pending.Done()
+	p.g.add(t.t)
+	defer p.g.finish(t.t)
+	p.g.started(t.t)
+	p.g.run(t.f, t.t)
+}
+
+// SetLimit limits the number of workers, and so of active functions, to at
+// most n. A negative value restores the default of [runtime.GOMAXPROCS].
+// A limit of zero will prevent any functions from being called.
+//
+// Lowering the limit does not stop workers that are already running; they
+// remain until Wait is called.
+func (p *Pool) SetLimit(n int) {
+	p.mu.Lock()
+	p.limited = n >= 0
+	p.limit = n
+	p.full.Store(false)
+	p.mu.Unlock()
+}
+
+// SetRecoverPanics controls whether the pool recovers panics in its
+// functions and re-raises them from Wait, as [Group.SetRecoverPanics] does.
+//
+// SetRecoverPanics must be called before any calls to Go.
+func (p *Pool) SetRecoverPanics(enable bool) {
+	p.g.SetRecoverPanics(enable)
+}
+
+// Wait blocks until all function calls from the Go method have returned and
+// stops the pool's workers, then returns the first non-nil error (if any)
+// from them, as [Group.Wait] does.
+//
+// The pool may be reused after Wait returns; its workers are started again
+// as needed.
+func (p *Pool) Wait() error {
+	p.pending.Wait()
+	p.stop()
+	return p.g.Wait()
+}
+
+// stop stops the workers and waits for them to exit.
+func (p *Pool) stop() {
+	p.mu.Lock()
+	quit := p.quit
+	p.quit = nil
+	p.mu.Unlock()
+	if quit == nil {
+		return
+	}
+	close(quit)
+	p.workers.Wait()
+	p.mu.Lock()
+	p.n = 0
+	p.full.Store(false)
+	p.mu.Unlock()
+}
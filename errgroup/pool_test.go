@@ -0,0 +1,141 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// goroutineID returns the ID of the calling goroutine, as shown in its stack
+// trace.
+func goroutineID() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	// The trace begins "goroutine N [status]:".
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	id, _, _ := bytes.Cut(buf, []byte(" "))
+	return string(id)
+}
+
+func TestPoolWorkers(t *testing.T) {
+	const limit = 3
+
+	var p errgroup.Pool
+	p.SetLimit(limit)
+	var (
+		mu         sync.Mutex
+		goroutines = make(map[string]bool)
+		active     atomic.Int32
+	)
+	for i := 0; i < 100; i++ {
+		p.Go(func() error {
+			if n := active.Add(1); n > limit {
+				t.Errorf("saw %d active functions; want ≤ %d", n, limit)
+			}
+			defer active.Add(-1)
+			mu.Lock()
+			goroutines[goroutineID()] = true
+			mu.Unlock()
+			time.Sleep(time.Microsecond) // Give other functions a chance to start.
+			return nil
+		})
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if len(goroutines) > limit {
+		t.Errorf("functions ran on %d goroutines; want ≤ %d", len(goroutines), limit)
+	}
+}
+
+func TestPoolError(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	p, ctx := errgroup.PoolWithContext(context.Background())
+	p.SetLimit(2)
+	p.Go(func() error {
+		<-ctx.Done()
+		return nil
+	})
+	p.Go(func() error { return errDoom })
+	if err := p.Wait(); err != errDoom {
+		t.Errorf("p.Wait() = %v; want %v", err, errDoom)
+	}
+	if cause := context.Cause(ctx); cause != errDoom {
+		t.Errorf("context.Cause(ctx) = %v; want %v", cause, errDoom)
+	}
+}
+
+func TestPoolPanic(t *testing.T) {
+	var p errgroup.Pool
+	p.SetLimit(1)
+	p.SetRecoverPanics(true)
+	p.Go(func() error { panicInTask("boom"); return nil })
+	// The worker survives the panic and runs the next function.
+	ran := false
+	p.Go(func() error { ran = true; return nil })
+
+	var v any
+	func() {
+		defer func() { v = recover() }()
+		p.Wait()
+	}()
+	if pe, ok := v.(*errgroup.PanicError); !ok || pe.Value != "boom" {
+		t.Errorf("p.Wait() panicked with %v; want a PanicError for \"boom\"", v)
+	}
+	if !ran {
+		t.Error("function after a panic did not run")
+	}
+}
+
+func TestPoolGoexit(t *testing.T) {
+	var p errgroup.Pool
+	p.SetLimit(1)
+	p.SetRecoverPanics(true)
+
+	returned := false
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		p.Go(func() error {
+			runtime.Goexit()
+			return nil
+		})
+		// The only worker is gone; the next function must still run.
+		p.Go(func() error { return nil })
+		p.Wait()
+		returned = true
+	}()
+	<-exited
+	if returned {
+		t.Errorf("p.Wait() returned; want it to call runtime.Goexit")
+	}
+}
+
+func TestPoolReuse(t *testing.T) {
+	var p errgroup.Pool
+	p.SetLimit(2)
+	for round := 0; round < 3; round++ {
+		var n atomic.Int32
+		for i := 0; i < 10; i++ {
+			p.Go(func() error { n.Add(1); return nil })
+		}
+		if err := p.Wait(); err != nil {
+			t.Fatal(err)
+		}
+		if got := n.Load(); got != 10 {
+			t.Errorf("round %d: ran %d functions; want 10", round, got)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrQuorumReached is the cause with which Quorum cancels the Context of the
+// functions still running once enough of them have succeeded.
+var ErrQuorumReached = errors.New("errgroup: quorum reached")
+
+// Quorum calls each of fns in its own goroutine and waits until k of them
+// have succeeded, as for a write to k of n replicas.
+//
+// The functions are passed a Context derived from ctx. As soon as k functions
+// have succeeded, the Context is canceled with cause [ErrQuorumReached] and
+// Quorum returns nil. As soon as so many have failed that k successes are no
+// longer possible, the Context is canceled with the error that made quorum
+// unreachable, and Quorum returns an error wrapping the errors of the failed
+// functions up to that point.
+//
+// Quorum does not wait for the functions still running once it is decided:
+// they are left to return in the background, and should do so promptly once
+// their Context is canceled. If one of them then panics, there is no caller
+// left to report the panic to, and it crashes the program. If a function
+// panics or calls [runtime.Goexit] before quorum is decided, Quorum waits for
+// the others to return and re-raises it, as [Group.Wait] does when it
+// recovers panics.
+//
+// Quorum returns nil without calling any function if k ≤ 0, and an error if
+// k > len(fns).
+func Quorum(ctx context.Context, k int, fns ...func(context.Context) error) error {
+	n := len(fns)
+	if k <= 0 {
+		return nil
+	}
+	if k > n {
+		return fmt.Errorf("errgroup: quorum of %d unreachable with %d functions", k, n)
+	}
+
+	g, ctx := WithContext(ctx)
+	g.SetRecoverPanics(true)
+	g.SetFailurePolicy(NeverCancel())
+	var (
+		mu        sync.Mutex
+		decided   bool
+		aborted   bool // a function panicked or called runtime.Goexit
+		succeeded int
+		errs      []error
+	)
+	done := make(chan struct{}) // closed once decided
+	decide := func() {
+		decided = true
+		close(done)
+	}
+	for _, fn := range fns {
+		g.Go(func() error {
+			normalReturn := false
+			defer func() {
+				if normalReturn {
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				if !decided {
+					aborted = true
+					decide()
+				}
+			}()
+			err := fn(ctx)
+			normalReturn = true
+
+			mu.Lock()
+			defer mu.Unlock()
+			if decided {
+				return err
+			}
+			if err != nil {
+				errs = append(errs, err)
+				if len(errs) > n-k {
+					decide()
+					g.cancel(err)
+				}
+				return err
+			}
+			succeeded++
+			if succeeded == k {
+				decide()
+				g.cancel(ErrQuorumReached)
+			}
+			return nil
+		})
+	}
+	<-done
+	if aborted {
+		g.Wait() // re-raises the panic or runtime.Goexit
+	}
+	go g.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if succeeded >= k {
+		return nil
+	}
+	return fmt.Errorf("errgroup: quorum of %d out of %d unreachable: %w", k, n, errors.Join(errs...))
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func TestQuorumReached(t *testing.T) {
+	causes := make(chan error, 1)
+	ok := func(context.Context) error { return nil }
+	stuck := func(ctx context.Context) error {
+		<-ctx.Done()
+		causes <- context.Cause(ctx)
+		return ctx.Err()
+	}
+
+	if err := errgroup.Quorum(context.Background(), 2, ok, stuck, ok); err != nil {
+		t.Errorf("Quorum() = %v; want nil", err)
+	}
+	if cause := <-causes; cause != errgroup.ErrQuorumReached {
+		t.Errorf("remaining function saw cause %v; want %v", cause, errgroup.ErrQuorumReached)
+	}
+}
+
+func TestQuorumUnreachable(t *testing.T) {
+	err1 := errors.New("errgroup_test: 1")
+	err2 := errors.New("errgroup_test: 2")
+
+	causes := make(chan error, 1)
+	fns := []func(context.Context) error{
+		func(context.Context) error { return err1 },
+		func(context.Context) error { return err2 },
+		// Two failures make a quorum of 2 out of 3 unreachable,
+		// which must cancel the last function.
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			causes <- context.Cause(ctx)
+			return ctx.Err()
+		},
+	}
+	err := errgroup.Quorum(context.Background(), 2, fns...)
+	if !errors.Is(err, err1) || !errors.Is(err, err2) {
+		t.Errorf("Quorum() = %v; want an error matching %v and %v", err, err1, err2)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("Quorum() = %v; want only the errors that made quorum unreachable", err)
+	}
+	if cause := <-causes; cause != err1 && cause != err2 {
+		t.Errorf("stuck function saw cause %v; want %v or %v", cause, err1, err2)
+	}
+
+	if err := errgroup.Quorum(context.Background(), 2, fns[0]); err == nil {
+		t.Errorf("Quorum() of 2 with 1 function = nil; want an error")
+	}
+}
+
+func TestQuorumReturnsEarly(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	ok := func(context.Context) error { return nil }
+	// Ignores cancellation, so Quorum must not wait for it.
+	slow := func(context.Context) error {
+		<-release
+		return nil
+	}
+	if err := errgroup.Quorum(context.Background(), 1, ok, slow); err != nil {
+		t.Errorf("Quorum() = %v; want nil", err)
+	}
+}
+
+func TestQuorumPanic(t *testing.T) {
+	var p any
+	func() {
+		defer func() { p = recover() }()
+		errgroup.Quorum(context.Background(), 1,
+			func(context.Context) error { panic("boom") },
+			func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		)
+	}()
+	if pe, ok := p.(*errgroup.PanicError); !ok || pe.Value != "boom" {
+		t.Errorf("Quorum() panicked with %v; want a PanicError for \"boom\"", p)
+	}
+}
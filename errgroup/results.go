@@ -0,0 +1,100 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"sync"
+)
+
+// Results is a Group whose functions each produce a value of type T.
+// Wait returns the values in the order the functions were submitted.
+//
+// A zero Results is valid, has no limit on the number of active goroutines,
+// and does not cancel on error.
+type Results[T any] struct {
+	g Group
+
+	mu   sync.Mutex // protects vals
+	vals []T
+}
+
+// ResultsWithContext returns a new Results and an associated Context derived
+// from ctx.
+//
+// The derived Context is canceled the first time a function passed to Go
+// returns a non-nil error or the first time Wait returns, whichever occurs
+// first.
+func ResultsWithContext[T any](ctx context.Context) (*Results[T], context.Context) {
+	r := new(Results[T])
+	return r, r.g.withContext(ctx)
+}
+
+// reserve allocates the result slot for the next function.
+// r.mu must be held.
+func (r *Results[T]) reserve() int {
+	var zero T
+	r.vals = append(r.vals, zero)
+	return len(r.vals) - 1
+}
+
+func (r *Results[T]) wrap(i int, f func() (T, error)) func() error {
+	return func() error {
+		v, err := f()
+		r.mu.Lock()
+		r.vals[i] = v
+		r.mu.Unlock()
+		return err
+	}
+}
+
+// Go calls the given function in a new goroutine and records its result.
+//
+// Go behaves like [Group.Go]: it blocks until the new goroutine can be added
+// without exceeding the configured limit, and the first function to return a
+// non-nil error cancels the associated Context, if any.
+func (r *Results[T]) Go(f func() (T, error)) {
+	r.mu.Lock()
+	i := r.reserve()
+	r.mu.Unlock()
+	r.g.Go(r.wrap(i, f))
+}
+
+// TryGo calls the given function in a new goroutine only if the number of
+// active goroutines is currently below the configured limit.
+//
+// The return value reports whether the goroutine was started. If it was not,
+// no result is recorded for f.
+func (r *Results[T]) TryGo(f func() (T, error)) bool {
+	// Hold r.mu so that no other call can take the slot after ours
+	// while we find out whether f was started.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.reserve()
+	if !r.g.TryGo(r.wrap(i, f)) {
+		r.vals = r.vals[:i]
+		return false
+	}
+	return true
+}
+
+// SetLimit limits the number of active goroutines to at most n,
+// as [Group.SetLimit] does.
+func (r *Results[T]) SetLimit(n int) {
+	r.g.SetLimit(n)
+}
+
+// Wait blocks until all function calls from the Go method have returned.
+// It returns their values in the order in which Go (or a successful TryGo)
+// was called, and the first non-nil error (if any) from them.
+//
+// The values are returned even if an error occurred; the slot of a function
+// that failed holds whatever value it returned alongside the error.
+func (r *Results[T]) Wait() ([]T, error) {
+	err := r.g.Wait()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.vals, err
+}
@@ -0,0 +1,110 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// This example uses Results to collect the values produced by a set of
+// parallel searches in the order they were started, without indexing into a
+// pre-sized slice.
+func ExampleResults() {
+	Google := func(ctx context.Context, query string) ([]Result, error) {
+		g, ctx := errgroup.ResultsWithContext[Result](ctx)
+		for _, search := range []Search{Web, Image, Video} {
+			g.Go(func() (Result, error) {
+				return search(ctx, query)
+			})
+		}
+		return g.Wait()
+	}
+
+	results, err := Google(context.Background(), "golang")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	for _, result := range results {
+		fmt.Println(result)
+	}
+
+	// Output:
+	// web result for "golang"
+	// image result for "golang"
+	// video result for "golang"
+}
+
+func TestResultsOrder(t *testing.T) {
+	var g errgroup.Results[int]
+	g.SetLimit(4)
+	const n = 100
+	for i := 0; i < n; i++ {
+		g.Go(func() (int, error) {
+			// Finish in roughly reverse order.
+			time.Sleep(time.Duration(n-i) * time.Microsecond)
+			return i, nil
+		})
+	}
+	got, err := g.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := make([]int, n)
+	for i := range want {
+		want[i] = i
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("g.Wait() = %v; want %v", got, want)
+	}
+}
+
+func TestResultsError(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	g, ctx := errgroup.ResultsWithContext[string](context.Background())
+	g.Go(func() (string, error) { return "a", nil })
+	g.Go(func() (string, error) { return "", errDoom })
+	g.Go(func() (string, error) {
+		<-ctx.Done()
+		return "c", context.Cause(ctx)
+	})
+
+	got, err := g.Wait()
+	if err != errDoom {
+		t.Errorf("g.Wait() error = %v; want %v", err, errDoom)
+	}
+	if want := []string{"a", "", "c"}; !slices.Equal(got, want) {
+		t.Errorf("g.Wait() = %q; want %q", got, want)
+	}
+}
+
+func TestResultsTryGo(t *testing.T) {
+	var g errgroup.Results[int]
+	g.SetLimit(1)
+	release := make(chan struct{})
+	if !g.TryGo(func() (int, error) { <-release; return 1, nil }) {
+		t.Fatal("TryGo should succeed but got fail.")
+	}
+	if g.TryGo(func() (int, error) { return 2, nil }) {
+		t.Fatal("TryGo should fail but succeeded.")
+	}
+	close(release)
+	got, err := g.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1}; !slices.Equal(got, want) {
+		t.Errorf("g.Wait() = %v; want %v", got, want)
+	}
+}
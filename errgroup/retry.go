@@ -0,0 +1,71 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// A RetryPolicy describes how GoRetry retries a failing function.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls, including the first.
+	// Values less than 1 are treated as 1.
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry. It doubles for each
+	// later retry, up to MaxBackoff if it is positive.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter randomly shortens each delay by up to this fraction of it,
+	// so that functions failing together do not retry in lockstep.
+	// It must be between 0 and 1.
+	Jitter float64
+
+	// Retryable, if not nil, reports whether an error is worth retrying.
+	// Other errors are returned right away.
+	Retryable func(error) bool
+}
+
+// delay returns how long to wait before retry number n, counting from 0.
+func (p RetryPolicy) delay(n int) time.Duration {
+	d := backoff(p.Backoff, p.MaxBackoff, n)
+	if p.Jitter > 0 && d > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// GoRetry is like Go, but calls f again when it fails, according to policy.
+// Only the error of the last call counts as the function's failure: earlier
+// errors are not reported and do not cancel the associated Context.
+//
+// Retrying stops once the group's Context, if any, is done; a pending
+// backoff delay is cut short and the last error is returned.
+func (g *Group) GoRetry(policy RetryPolicy, f func() error) {
+	g.Go(func() error {
+		var done <-chan struct{}
+		if g.ctx != nil {
+			done = g.ctx.Done()
+		}
+		for attempt := 1; ; attempt++ {
+			err := f()
+			if err == nil || attempt >= policy.MaxAttempts {
+				return err
+			}
+			if policy.Retryable != nil && !policy.Retryable(err) {
+				return err
+			}
+			timer := time.NewTimer(policy.delay(attempt - 1))
+			select {
+			case <-timer.C:
+			case <-done:
+				timer.Stop()
+				return err
+			}
+		}
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func TestGoRetry(t *testing.T) {
+	g, ctx := errgroup.WithContext(context.Background())
+	var calls atomic.Int32
+	g.GoRetry(errgroup.RetryPolicy{MaxAttempts: 3, Backoff: 1 * time.Microsecond, Jitter: 0.5}, func() error {
+		if n := calls.Add(1); n < 3 {
+			return fmt.Errorf("attempt %d failed", n)
+		}
+		if ctx.Err() != nil {
+			return errors.New("a retried failure canceled the group")
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("f called %d times; want 3", n)
+	}
+}
+
+func TestGoRetryLastError(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	cases := []struct {
+		name   string
+		policy errgroup.RetryPolicy
+		want   string
+		calls  int32
+	}{
+		{"exhausted", errgroup.RetryPolicy{MaxAttempts: 3}, "attempt 3 failed", 3},
+		{"zero", errgroup.RetryPolicy{}, "attempt 1 failed", 1},
+		{"not retryable", errgroup.RetryPolicy{
+			MaxAttempts: 3,
+			Retryable:   func(err error) bool { return !errors.Is(err, errPermanent) },
+		}, "attempt 2 failed: permanent", 2},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := new(errgroup.Group)
+			var calls atomic.Int32
+			g.GoRetry(tc.policy, func() error {
+				n := calls.Add(1)
+				if n == 2 {
+					return fmt.Errorf("attempt %d failed: %w", n, errPermanent)
+				}
+				return fmt.Errorf("attempt %d failed", n)
+			})
+			if err := g.Wait(); err == nil || err.Error() != tc.want {
+				t.Errorf("g.Wait() = %v; want %s", err, tc.want)
+			}
+			if n := calls.Load(); n != tc.calls {
+				t.Errorf("f called %d times; want %d", n, tc.calls)
+			}
+		})
+	}
+}
+
+func TestGoRetryCanceled(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	g, _ := errgroup.WithContext(context.Background())
+	retried := make(chan struct{})
+	var calls atomic.Int32
+	g.GoRetry(errgroup.RetryPolicy{MaxAttempts: 100, Backoff: 1 * time.Hour}, func() error {
+		if calls.Add(1) == 1 {
+			close(retried)
+		}
+		return errors.New("retryable")
+	})
+	g.Go(func() error {
+		<-retried
+		return errDoom
+	})
+
+	start := time.Now()
+	if err := g.Wait(); err != errDoom {
+		t.Errorf("g.Wait() = %v; want %v", err, errDoom)
+	}
+	if elapsed := time.Since(start); elapsed > 1*time.Minute {
+		t.Errorf("g.Wait() took %v; want the backoff cut short by cancellation", elapsed)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("f called %d times; want 1", n)
+	}
+}
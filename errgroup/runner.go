@@ -0,0 +1,143 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// A Service is a long-running component, such as a server, run by a Runner.
+type Service struct {
+	// Name identifies the service in errors and profiles; see Group.GoNamed.
+	Name string
+
+	// Run runs the service until it is stopped, or fails.
+	// Its Context is canceled after Stop returns. Once Stop has been
+	// called, the error returned by Run is taken to be the result of
+	// stopping, such as http.ErrServerClosed, and is not reported.
+	Run func(ctx context.Context) error
+
+	// Stop, if not nil, asks Run to return, for example by shutting down a
+	// server gracefully. Its Context expires after StopTimeout.
+	Stop func(ctx context.Context) error
+
+	// StopTimeout bounds how long stopping the service may take, including
+	// waiting for Run to return after Stop. Zero means no limit.
+	StopTimeout time.Duration
+}
+
+// A Runner runs a set of services side by side and shuts them down in order.
+//
+// A zero Runner is valid and has no services.
+type Runner struct {
+	services []Service
+}
+
+// Add registers a service to be started by Run.
+// Services are started in the order they were added, and stopped in the
+// reverse order.
+func (r *Runner) Add(s Service) {
+	r.services = append(r.services, s)
+}
+
+// Run starts every service in its own goroutine and blocks until they have
+// all stopped.
+//
+// The first time a service's Run returns a non-nil error, or when ctx is
+// canceled, Run shuts the services down one at a time, from the last added to
+// the first: it calls the service's Stop function, cancels the Context passed
+// to its Run function and waits for Run to return, all within the service's
+// StopTimeout. A service that has not returned in time is abandoned, and
+// reported as an error; the Context of the group it runs in is canceled, and
+// if it later panics, the panic is re-raised in a new goroutine, crashing the
+// program rather than going unreported.
+//
+// The Contexts passed to the services carry the values of ctx, but are not
+// canceled with it, so that the services stop in order. Services that return
+// nil before shutdown begins simply leave the set.
+//
+// Run returns the errors of all the services, from running and from stopping,
+// joined with [errors.Join] and each prefixed with the service's name. An error
+// returned by a service's Run after the Runner has begun to stop that service
+// is part of an orderly shutdown and not reported.
+func (r *Runner) Run(ctx context.Context) error {
+	g, gctx := WithContext(ctx)
+	g.SetRecoverPanics(true)
+	base := context.WithoutCancel(ctx)
+
+	type running struct {
+		cancel   context.CancelFunc
+		stopping atomic.Bool   // set before the service is stopped
+		done     chan struct{} // closed when Run has returned
+		err      error         // returned by Run; written before done is closed
+	}
+	rs := make([]*running, len(r.services))
+	for i, s := range r.services {
+		sctx, cancel := context.WithCancel(base)
+		rs[i] = &running{cancel: cancel, done: make(chan struct{})}
+		g.GoNamed(s.Name, func() error {
+			defer close(rs[i].done)
+			err := s.Run(sctx)
+			if rs[i].stopping.Load() {
+				err = nil
+			}
+			rs[i].err = err
+			return err
+		})
+	}
+
+	// Wait for a failure, for cancellation, or for every service to return.
+	select {
+	case <-gctx.Done():
+	case <-g.Done():
+	}
+
+	var stopErrs []error
+	var abandoned error
+	for i := len(r.services) - 1; i >= 0; i-- {
+		s, rn := r.services[i], rs[i]
+		stopCtx, cancel := context.Background(), context.CancelFunc(func() {})
+		if s.StopTimeout > 0 {
+			stopCtx, cancel = context.WithTimeout(stopCtx, s.StopTimeout)
+		}
+		rn.stopping.Store(true)
+		if s.Stop != nil {
+			if err := s.Stop(stopCtx); err != nil {
+				stopErrs = append(stopErrs, fmt.Errorf("%s: stop: %w", s.Name, err))
+			}
+		}
+		rn.cancel()
+		select {
+		case <-rn.done:
+		case <-stopCtx.Done():
+			abandoned = fmt.Errorf("%s: did not stop within %v", s.Name, s.StopTimeout)
+			stopErrs = append(stopErrs, abandoned)
+		}
+		cancel()
+	}
+	if abandoned == nil {
+		// Re-raise panics from the services, if any.
+		g.Wait()
+	} else {
+		g.cancel(abandoned)
+		go g.Wait()
+	}
+
+	var errs []error
+	for i, rn := range rs {
+		select {
+		case <-rn.done:
+			if rn.err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", r.services[i].Name, rn.err))
+			}
+		default:
+		}
+	}
+	return errors.Join(append(errs, stopErrs...)...)
+}
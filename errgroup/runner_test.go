@@ -0,0 +1,164 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// server is a fake service that runs until it is stopped.
+type server struct {
+	name    string
+	mu      *sync.Mutex
+	stopped *[]string
+	quit    chan struct{}
+}
+
+func (s *server) service() errgroup.Service {
+	return errgroup.Service{
+		Name: s.name,
+		Run: func(ctx context.Context) error {
+			<-s.quit
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			s.mu.Lock()
+			*s.stopped = append(*s.stopped, s.name)
+			s.mu.Unlock()
+			close(s.quit)
+			return nil
+		},
+	}
+}
+
+func ExampleRunner() {
+	var r errgroup.Runner
+	for _, name := range []string{"database", "cache", "http"} {
+		quit := make(chan struct{})
+		r.Add(errgroup.Service{
+			Name: name,
+			Run: func(ctx context.Context) error {
+				<-quit
+				return nil
+			},
+			Stop: func(ctx context.Context) error {
+				fmt.Println("stopping", name)
+				close(quit)
+				return nil
+			},
+			StopTimeout: 5 * time.Second,
+		})
+	}
+
+	// Shut down after a while, as on a signal.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.Run(ctx); err != nil {
+		fmt.Println(err)
+	}
+
+	// Output:
+	// stopping http
+	// stopping cache
+	// stopping database
+}
+
+func TestRunnerStopsInReverseOrder(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	var (
+		r       errgroup.Runner
+		mu      sync.Mutex
+		stopped []string
+	)
+	for _, name := range []string{"a", "b", "c"} {
+		s := &server{name: name, mu: &mu, stopped: &stopped, quit: make(chan struct{})}
+		r.Add(s.service())
+	}
+	r.Add(errgroup.Service{
+		Name: "failing",
+		Run:  func(ctx context.Context) error { return errDoom },
+		Stop: func(ctx context.Context) error { return errors.New("already stopped") },
+	})
+
+	err := r.Run(context.Background())
+	if want := []string{"c", "b", "a"}; !slices.Equal(stopped, want) {
+		t.Errorf("services stopped in order %v; want %v", stopped, want)
+	}
+	if !errors.Is(err, errDoom) {
+		t.Errorf("r.Run() = %v; want an error matching %v", err, errDoom)
+	}
+	want := "failing: group_test: doomed\nfailing: stop: already stopped"
+	if err == nil || err.Error() != want {
+		t.Errorf("r.Run() = %q; want %q", err, want)
+	}
+}
+
+func TestRunnerStopTimeout(t *testing.T) {
+	stuck := make(chan struct{})
+	defer close(stuck)
+
+	var r errgroup.Runner
+	r.Add(errgroup.Service{
+		Name: "canceled",
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	r.Add(errgroup.Service{
+		Name: "stuck",
+		Run: func(ctx context.Context) error {
+			<-stuck
+			return nil
+		},
+		StopTimeout: 1 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := r.Run(ctx)
+	if err == nil || err.Error() != "stuck: did not stop within 1ms" {
+		t.Errorf("r.Run() = %v; want only the stuck service reported", err)
+	}
+	if err != nil && strings.Contains(err.Error(), "canceled") {
+		t.Errorf("r.Run() = %v; want the orderly cancellation not reported", err)
+	}
+}
+
+func TestRunnerStopError(t *testing.T) {
+	errClosed := errors.New("group_test: server closed")
+
+	// Like an http.Server, whose ListenAndServe returns ErrServerClosed
+	// once Shutdown is called.
+	quit := make(chan struct{})
+	var r errgroup.Runner
+	r.Add(errgroup.Service{
+		Name: "http",
+		Run: func(context.Context) error {
+			<-quit
+			return errClosed
+		},
+		Stop: func(context.Context) error {
+			close(quit)
+			return nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := r.Run(ctx); err != nil {
+		t.Errorf("r.Run() = %v; want nil after a graceful stop", err)
+	}
+}
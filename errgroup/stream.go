@@ -0,0 +1,129 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"sync"
+)
+
+// errStopped is the cause with which a Stream cancels its Context when the
+// iteration over its results stops early.
+var errStopped = errors.New("errgroup: stream iteration stopped")
+
+// A Stream is a Group whose functions each produce a value of type T, which
+// are delivered by All in the order in which the functions return.
+//
+// A zero Stream is valid, has no limit on the number of active goroutines,
+// and does not cancel on error.
+type Stream[T any] struct {
+	g Group
+
+	mu     sync.Mutex // protects queue and notify
+	queue  []streamResult[T]
+	notify chan struct{} // receives a value when queue becomes non-empty
+}
+
+type streamResult[T any] struct {
+	v   T
+	err error
+}
+
+// StreamWithContext returns a new Stream and an associated Context derived
+// from ctx.
+//
+// The derived Context is canceled the first time a function passed to Go
+// returns a non-nil error, the first time an iteration over All stops early,
+// or when the iteration ends, whichever occurs first.
+func StreamWithContext[T any](ctx context.Context) (*Stream[T], context.Context) {
+	s := new(Stream[T])
+	return s, s.g.withContext(ctx)
+}
+
+// notifyChan returns s.notify, creating it if needed. s.mu must be held.
+func (s *Stream[T]) notifyChan() chan struct{} {
+	if s.notify == nil {
+		s.notify = make(chan struct{}, 1)
+	}
+	return s.notify
+}
+
+// Go calls the given function in a new goroutine and queues its result for
+// All. It blocks as [Group.Go] does.
+//
+// Go must be called before the iteration over All starts, or from within the
+// loop body, so that the iteration does not end before f is queued.
+func (s *Stream[T]) Go(f func() (T, error)) {
+	s.g.Go(func() error {
+		v, err := f()
+		s.mu.Lock()
+		s.queue = append(s.queue, streamResult[T]{v, err})
+		select {
+		case s.notifyChan() <- struct{}{}:
+		default:
+		}
+		s.mu.Unlock()
+		return err
+	})
+}
+
+// SetLimit limits the number of active goroutines to at most n,
+// as [Group.SetLimit] does.
+func (s *Stream[T]) SetLimit(n int) {
+	s.g.SetLimit(n)
+}
+
+// pop removes the oldest queued result, reporting whether there was one.
+func (s *Stream[T]) pop() (streamResult[T], bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.queue) == 0 {
+		return streamResult[T]{}, false
+	}
+	r := s.queue[0]
+	s.queue = s.queue[1:]
+	return r, true
+}
+
+// All returns an iterator over the value and error of each function passed
+// to Go, in the order in which the functions return. The iteration ends
+// once every function has returned and its result has been yielded.
+//
+// Stopping the iteration early cancels the associated Context, if any, and
+// waits for the remaining functions to return, discarding their results.
+func (s *Stream[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			if r, ok := s.pop(); ok {
+				if !yield(r.v, r.err) {
+					if s.g.cancel != nil {
+						s.g.cancel(errStopped)
+					}
+					s.g.Wait()
+					return
+				}
+				continue
+			}
+			s.mu.Lock()
+			notify := s.notifyChan()
+			s.mu.Unlock()
+			select {
+			case <-notify:
+			case <-s.g.Done():
+				// Every function has queued its result before
+				// returning, so only the queue is left to drain.
+				s.mu.Lock()
+				drained := len(s.queue) == 0
+				s.mu.Unlock()
+				if drained {
+					s.g.Wait()
+					return
+				}
+			}
+		}
+	}
+}
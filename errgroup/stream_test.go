@@ -0,0 +1,109 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func ExampleStream() {
+	s, _ := errgroup.StreamWithContext[string](context.Background())
+	for _, d := range []time.Duration{300, 100, 200} {
+		s.Go(func() (string, error) {
+			time.Sleep(d * time.Millisecond)
+			return fmt.Sprintf("slept %dms", d), nil
+		})
+	}
+	for v, err := range s.All() {
+		if err != nil {
+			fmt.Println(err)
+			break
+		}
+		fmt.Println(v)
+	}
+
+	// Output:
+	// slept 100ms
+	// slept 200ms
+	// slept 300ms
+}
+
+func TestStreamCompletionOrder(t *testing.T) {
+	var s errgroup.Stream[int]
+	release := make([]chan struct{}, 5)
+	for i := range release {
+		release[i] = make(chan struct{})
+		s.Go(func() (int, error) {
+			<-release[i]
+			return i, nil
+		})
+	}
+
+	// Release the functions in reverse order, one per result.
+	var got []int
+	close(release[len(release)-1])
+	for v, err := range s.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+		if next := len(release) - 1 - len(got); next >= 0 {
+			close(release[next])
+		}
+	}
+	if want := []int{4, 3, 2, 1, 0}; !slices.Equal(got, want) {
+		t.Errorf("s.All() yielded %v; want %v", got, want)
+	}
+}
+
+func TestStreamStopCancels(t *testing.T) {
+	s, ctx := errgroup.StreamWithContext[int](context.Background())
+	s.Go(func() (int, error) { return 1, nil })
+	canceled := make(chan bool, 1)
+	s.Go(func() (int, error) {
+		select {
+		case <-ctx.Done():
+			canceled <- true
+		case <-time.After(1 * time.Minute):
+			canceled <- false
+		}
+		return 2, ctx.Err()
+	})
+
+	for v := range s.All() {
+		if v != 1 {
+			t.Errorf("first value = %d; want 1", v)
+		}
+		break
+	}
+	if !<-canceled {
+		t.Errorf("stopping the iteration did not cancel the remaining function")
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	s, ctx := errgroup.StreamWithContext[int](context.Background())
+	s.Go(func() (int, error) { return 0, errDoom })
+	s.Go(func() (int, error) {
+		<-ctx.Done()
+		return 0, context.Cause(ctx)
+	})
+	var errs []error
+	for _, err := range s.All() {
+		errs = append(errs, err)
+	}
+	if want := []error{errDoom, errDoom}; !slices.Equal(errs, want) {
+		t.Errorf("s.All() yielded errors %v; want %v", errs, want)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRestartLimit is wrapped by the error a Supervisor returns when a child
+// fails after the restart budget of its RestartPolicy has been used up.
+var ErrRestartLimit = errors.New("errgroup: restart limit exceeded")
+
+// A RestartStrategy selects which children a Supervisor restarts when one
+// of them fails.
+type RestartStrategy int
+
+const (
+	// OneForOne restarts only the child that failed.
+	OneForOne RestartStrategy = iota
+
+	// OneForAll cancels the other children when one fails, waits for them
+	// to return, and then restarts all of them.
+	OneForAll
+)
+
+// A RestartPolicy describes how a Supervisor restarts failed children.
+type RestartPolicy struct {
+	Strategy RestartStrategy
+
+	// MaxRestarts is the number of restarts allowed within Window.
+	// A child that fails once the budget is used up is not restarted:
+	// the Supervisor gives up instead.
+	MaxRestarts int
+
+	// Window is the period over which restarts are counted.
+	// Zero means the whole run of the Supervisor.
+	Window time.Duration
+
+	// Backoff is the delay before a restart when no other restart happened
+	// within Window. Each restart already counted within Window doubles the
+	// delay, up to MaxBackoff if it is positive.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// A Supervisor runs a set of long-lived functions, its children, and
+// restarts those that fail according to a RestartPolicy, in the manner of an
+// Erlang supervisor.
+//
+// A child fails if it returns a non-nil error or panics. A child that returns
+// nil has finished and is not restarted, except along with the others under
+// the OneForAll strategy.
+type Supervisor struct {
+	policy   RestartPolicy
+	children []supervised
+
+	mu       sync.Mutex
+	restarts []time.Time // within the policy's Window
+}
+
+type supervised struct {
+	name string
+	run  func(context.Context) error
+}
+
+// NewSupervisor returns a Supervisor that restarts its children according to
+// the given policy.
+func NewSupervisor(policy RestartPolicy) *Supervisor {
+	return &Supervisor{policy: policy}
+}
+
+// Add adds a child to be started by Run. The name identifies the child in
+// errors and profiles; see Group.GoNamed.
+func (s *Supervisor) Add(name string, run func(ctx context.Context) error) {
+	s.children = append(s.children, supervised{name, run})
+}
+
+// Run runs the children, each in its own goroutine, until they have all
+// finished or ctx is canceled, and returns nil; or until the restart budget is
+// used up, in which case it cancels the remaining children and returns the
+// error of the child that failed last, wrapping [ErrRestartLimit].
+func (s *Supervisor) Run(ctx context.Context) error {
+	if s.policy.Strategy == OneForAll {
+		return s.runOneForAll(ctx)
+	}
+
+	g, ctx := WithContext(ctx)
+	for _, c := range s.children {
+		g.GoNamed(c.name, func() error {
+			for {
+				err := protect(func() error { return c.run(ctx) })
+				if err == nil || ctx.Err() != nil {
+					return nil
+				}
+				if !s.restart(ctx) {
+					if ctx.Err() != nil {
+						return nil
+					}
+					return fmt.Errorf("%w: %w", ErrRestartLimit, err)
+				}
+			}
+		})
+	}
+	return g.Wait()
+}
+
+func (s *Supervisor) runOneForAll(ctx context.Context) error {
+	for {
+		g, gctx := WithContext(ctx)
+		for _, c := range s.children {
+			g.GoNamed(c.name, func() error {
+				return protect(func() error { return c.run(gctx) })
+			})
+		}
+		err := g.Wait()
+		if err == nil || ctx.Err() != nil {
+			return nil
+		}
+		if !s.restart(ctx) {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("%w: %w", ErrRestartLimit, err)
+		}
+	}
+}
+
+// restart records a restart and sleeps for the backoff delay. It reports
+// false if the restart budget is used up or ctx is done before the delay has
+// passed.
+func (s *Supervisor) restart(ctx context.Context) bool {
+	now := time.Now()
+	s.mu.Lock()
+	if s.policy.Window > 0 {
+		cutoff := now.Add(-s.policy.Window)
+		i := 0
+		for i < len(s.restarts) && s.restarts[i].Before(cutoff) {
+			i++
+		}
+		s.restarts = s.restarts[i:]
+	}
+	recent := len(s.restarts)
+	if recent >= s.policy.MaxRestarts {
+		s.mu.Unlock()
+		return false
+	}
+	s.restarts = append(s.restarts, now)
+	s.mu.Unlock()
+
+	delay := backoff(s.policy.Backoff, s.policy.MaxBackoff, recent)
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// backoff returns base doubled n times, capped at limit if it is positive.
+func backoff(base, limit time.Duration, n int) time.Duration {
+	d := base
+	for ; n > 0 && d > 0; n-- {
+		if limit > 0 && d >= limit {
+			break
+		}
+		if d > math.MaxInt64/2 {
+			break // Avoid overflow.
+		}
+		d *= 2
+	}
+	if limit > 0 && d > limit {
+		d = limit
+	}
+	return d
+}
+
+// protect calls f, returning a panic in f as a *PanicError.
+func protect(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = newPanicError(r)
+		}
+	}()
+	return f()
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errgroup_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+func TestSupervisorOneForOne(t *testing.T) {
+	s := errgroup.NewSupervisor(errgroup.RestartPolicy{
+		Strategy:    errgroup.OneForOne,
+		MaxRestarts: 5,
+		Backoff:     1 * time.Microsecond,
+	})
+	var flakyRuns, steadyRuns atomic.Int32
+	s.Add("flaky", func(ctx context.Context) error {
+		switch flakyRuns.Add(1) {
+		case 1:
+			return errors.New("transient failure")
+		case 2:
+			panic("transient panic")
+		}
+		return nil
+	})
+	s.Add("steady", func(ctx context.Context) error {
+		steadyRuns.Add(1)
+		return nil
+	})
+	if err := s.Run(context.Background()); err != nil {
+		t.Fatalf("s.Run() = %v; want nil", err)
+	}
+	if n := flakyRuns.Load(); n != 3 {
+		t.Errorf("flaky child ran %d times; want 3", n)
+	}
+	if n := steadyRuns.Load(); n != 1 {
+		t.Errorf("steady child ran %d times; want 1", n)
+	}
+}
+
+func TestSupervisorRestartLimit(t *testing.T) {
+	errDoom := errors.New("group_test: doomed")
+
+	s := errgroup.NewSupervisor(errgroup.RestartPolicy{
+		MaxRestarts: 3,
+		Window:      1 * time.Hour,
+	})
+	var runs atomic.Int32
+	s.Add("doomed", func(ctx context.Context) error {
+		runs.Add(1)
+		return errDoom
+	})
+	s.Add("server", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	err := s.Run(context.Background())
+	if !errors.Is(err, errgroup.ErrRestartLimit) || !errors.Is(err, errDoom) {
+		t.Errorf("s.Run() = %v; want an error matching %v and %v", err, errgroup.ErrRestartLimit, errDoom)
+	}
+	if err != nil && !strings.HasPrefix(err.Error(), "doomed: ") {
+		t.Errorf("s.Run() = %v; want it to name the failing child", err)
+	}
+	if n := runs.Load(); n != 4 {
+		t.Errorf("doomed child ran %d times; want 4", n)
+	}
+}
+
+func TestSupervisorOneForAll(t *testing.T) {
+	s := errgroup.NewSupervisor(errgroup.RestartPolicy{
+		Strategy:    errgroup.OneForAll,
+		MaxRestarts: 1,
+	})
+	var flakyRuns, siblingRuns atomic.Int32
+	s.Add("flaky", func(ctx context.Context) error {
+		if flakyRuns.Add(1) == 1 {
+			return errors.New("transient failure")
+		}
+		return nil
+	})
+	s.Add("sibling", func(ctx context.Context) error {
+		if siblingRuns.Add(1) == 1 {
+			// Runs until the failure of flaky cancels it.
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	})
+	if err := s.Run(context.Background()); err != nil {
+		t.Fatalf("s.Run() = %v; want nil", err)
+	}
+	if n := siblingRuns.Load(); n != 2 {
+		t.Errorf("sibling ran %d times; want it restarted along with flaky", n)
+	}
+}
+
+func TestSupervisorCanceled(t *testing.T) {
+	s := errgroup.NewSupervisor(errgroup.RestartPolicy{
+		MaxRestarts: 1 << 20,
+		Backoff:     1 * time.Hour,
+	})
+	s.Add("failing", func(ctx context.Context) error {
+		return errors.New("failure")
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := s.Run(ctx); err != nil {
+		t.Errorf("s.Run() = %v; want nil once ctx is canceled during backoff", err)
+	}
+}